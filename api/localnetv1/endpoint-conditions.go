@@ -15,3 +15,8 @@ limitations under the License.
 */
 
 package localnetv1
+
+// IsServingTerminating returns true if the endpoint is terminating but still able to serve traffic.
+func (c *EndpointConditions) IsServingTerminating() bool {
+	return c.GetServing() && c.GetTerminating()
+}
@@ -47,3 +47,21 @@ func (ep *Endpoint) PortMappings(ports []*PortMapping) (mapping map[int32]int32)
 	}
 	return
 }
+
+// EffectiveWeight returns the load-balancing weight of this endpoint, ie: 1 when no weight is set.
+func (ep *Endpoint) EffectiveWeight() int32 {
+	if ep.Weight <= 0 {
+		return 1
+	}
+	return ep.Weight
+}
+
+// IsInternal returns true if the endpoint is eligible for internal traffic (to cluster IPs).
+func (ep *Endpoint) IsInternal() bool {
+	return !ep.NotInternal
+}
+
+// IsExternal returns true if the endpoint is eligible for external traffic (to node ports, external IPs and load-balancer IPs).
+func (ep *Endpoint) IsExternal() bool {
+	return !ep.NotExternal
+}
@@ -37,9 +37,11 @@ const (
 type Set int32
 
 const (
-	Set_UnknownSet          Set = 0
-	Set_ServicesSet         Set = 1
-	Set_EndpointsSet        Set = 2
+	Set_UnknownSet   Set = 0
+	Set_ServicesSet  Set = 1
+	Set_EndpointsSet Set = 2
+	// the requesting node's record (on the local API)
+	Set_NodesSet            Set = 3
 	Set_GlobalServiceInfos  Set = 10
 	Set_GlobalEndpointInfos Set = 11
 	Set_GlobalNodeInfos     Set = 12
@@ -51,6 +53,7 @@ var (
 		0:  "UnknownSet",
 		1:  "ServicesSet",
 		2:  "EndpointsSet",
+		3:  "NodesSet",
 		10: "GlobalServiceInfos",
 		11: "GlobalEndpointInfos",
 		12: "GlobalNodeInfos",
@@ -59,6 +62,7 @@ var (
 		"UnknownSet":          0,
 		"ServicesSet":         1,
 		"EndpointsSet":        2,
+		"NodesSet":            3,
 		"GlobalServiceInfos":  10,
 		"GlobalEndpointInfos": 11,
 		"GlobalNodeInfos":     12,
@@ -151,6 +155,15 @@ type WatchReq struct {
 
 	// NodeName of the requester
 	NodeName string `protobuf:"bytes,1,opt,name=NodeName,proto3" json:"NodeName,omitempty"`
+	// LastRevision is the last revision fully received by the requester (ie: before a reconnection).
+	// If the server can still compute the changes since that revision, only those are sent; otherwise
+	// a Reset is sent, followed by the whole data set.
+	LastRevision *Revision `protobuf:"bytes,2,opt,name=LastRevision,proto3" json:"LastRevision,omitempty"`
+	// Filter on the services to watch (all if not set)
+	Filter *WatchFilter `protobuf:"bytes,3,opt,name=Filter,proto3" json:"Filter,omitempty"`
+	// ApplyStatus is the result of the application of the last change set received by the requester
+	// (not set if the requester doesn't report it)
+	ApplyStatus *ApplyStatus `protobuf:"bytes,4,opt,name=ApplyStatus,proto3" json:"ApplyStatus,omitempty"`
 }
 
 func (x *WatchReq) Reset() {
@@ -192,6 +205,169 @@ func (x *WatchReq) GetNodeName() string {
 	return ""
 }
 
+func (x *WatchReq) GetLastRevision() *Revision {
+	if x != nil {
+		return x.LastRevision
+	}
+	return nil
+}
+
+func (x *WatchReq) GetFilter() *WatchFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *WatchReq) GetApplyStatus() *ApplyStatus {
+	if x != nil {
+		return x.ApplyStatus
+	}
+	return nil
+}
+
+type ApplyStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Revision of the change set applied
+	Revision *Revision `protobuf:"bytes,1,opt,name=Revision,proto3" json:"Revision,omitempty"`
+	// Error of the application (empty if it succeeded)
+	Error string `protobuf:"bytes,2,opt,name=Error,proto3" json:"Error,omitempty"`
+	// FailedServices are the services (as namespace/name) that could not be applied, if known
+	FailedServices []string `protobuf:"bytes,3,rep,name=FailedServices,proto3" json:"FailedServices,omitempty"`
+}
+
+func (x *ApplyStatus) Reset() {
+	*x = ApplyStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_localnetv1_services_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyStatus) ProtoMessage() {}
+
+func (x *ApplyStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyStatus.ProtoReflect.Descriptor instead.
+func (*ApplyStatus) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ApplyStatus) GetRevision() *Revision {
+	if x != nil {
+		return x.Revision
+	}
+	return nil
+}
+
+func (x *ApplyStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ApplyStatus) GetFailedServices() []string {
+	if x != nil {
+		return x.FailedServices
+	}
+	return nil
+}
+
+type WatchFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Namespaces to include (all if empty)
+	Namespaces []string `protobuf:"bytes,1,rep,name=Namespaces,proto3" json:"Namespaces,omitempty"`
+	// Namespaces to exclude
+	ExcludedNamespaces []string `protobuf:"bytes,2,rep,name=ExcludedNamespaces,proto3" json:"ExcludedNamespaces,omitempty"`
+	// LabelSelector on the services' labels, in the usual format (ie: "app=web,tier!=db").
+	// Only the labels included by the server can be matched.
+	LabelSelector string `protobuf:"bytes,3,opt,name=LabelSelector,proto3" json:"LabelSelector,omitempty"`
+	// ServiceTypes to include (all if empty)
+	ServiceTypes []string `protobuf:"bytes,4,rep,name=ServiceTypes,proto3" json:"ServiceTypes,omitempty"`
+}
+
+func (x *WatchFilter) Reset() {
+	*x = WatchFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_localnetv1_services_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchFilter) ProtoMessage() {}
+
+func (x *WatchFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchFilter.ProtoReflect.Descriptor instead.
+func (*WatchFilter) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WatchFilter) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *WatchFilter) GetExcludedNamespaces() []string {
+	if x != nil {
+		return x.ExcludedNamespaces
+	}
+	return nil
+}
+
+func (x *WatchFilter) GetLabelSelector() string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return ""
+}
+
+func (x *WatchFilter) GetServiceTypes() []string {
+	if x != nil {
+		return x.ServiceTypes
+	}
+	return nil
+}
+
 type OpItem struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -203,12 +379,14 @@ type OpItem struct {
 	//	*OpItem_Set
 	//	*OpItem_Delete
 	Op isOpItem_Op `protobuf_oneof:"Op"`
+	// Revision of the data set, given with Sync (if the server supports resuming watches)
+	Revision *Revision `protobuf:"bytes,5,opt,name=Revision,proto3" json:"Revision,omitempty"`
 }
 
 func (x *OpItem) Reset() {
 	*x = OpItem{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[1]
+		mi := &file_api_localnetv1_services_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -221,7 +399,7 @@ func (x *OpItem) String() string {
 func (*OpItem) ProtoMessage() {}
 
 func (x *OpItem) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[1]
+	mi := &file_api_localnetv1_services_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -234,7 +412,7 @@ func (x *OpItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpItem.ProtoReflect.Descriptor instead.
 func (*OpItem) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{1}
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{3}
 }
 
 func (m *OpItem) GetOp() isOpItem_Op {
@@ -272,6 +450,13 @@ func (x *OpItem) GetDelete() *Ref {
 	return nil
 }
 
+func (x *OpItem) GetRevision() *Revision {
+	if x != nil {
+		return x.Revision
+	}
+	return nil
+}
+
 type isOpItem_Op interface {
 	isOpItem_Op()
 }
@@ -304,6 +489,62 @@ func (*OpItem_Set) isOpItem_Op() {}
 
 func (*OpItem_Delete) isOpItem_Op() {}
 
+type Revision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Epoch identifies the server's store instance; revisions from different epochs are unrelated.
+	Epoch uint64 `protobuf:"varint,1,opt,name=Epoch,proto3" json:"Epoch,omitempty"`
+	Rev   uint64 `protobuf:"varint,2,opt,name=Rev,proto3" json:"Rev,omitempty"`
+}
+
+func (x *Revision) Reset() {
+	*x = Revision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_localnetv1_services_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Revision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revision) ProtoMessage() {}
+
+func (x *Revision) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revision.ProtoReflect.Descriptor instead.
+func (*Revision) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Revision) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *Revision) GetRev() uint64 {
+	if x != nil {
+		return x.Rev
+	}
+	return 0
+}
+
 type EmptyOp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -313,7 +554,7 @@ type EmptyOp struct {
 func (x *EmptyOp) Reset() {
 	*x = EmptyOp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[2]
+		mi := &file_api_localnetv1_services_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -326,7 +567,7 @@ func (x *EmptyOp) String() string {
 func (*EmptyOp) ProtoMessage() {}
 
 func (x *EmptyOp) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[2]
+	mi := &file_api_localnetv1_services_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -339,35 +580,39 @@ func (x *EmptyOp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmptyOp.ProtoReflect.Descriptor instead.
 func (*EmptyOp) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{2}
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{5}
 }
 
-type Ref struct {
+// RecordedOp is an operation sent to a local sink, as recorded by the recording sink (see kpng replay).
+// Records are written length-delimited (varint size, then message).
+type RecordedOp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Set  Set    `protobuf:"varint,1,opt,name=Set,proto3,enum=localnetv1.Set" json:"Set,omitempty"`
-	Path string `protobuf:"bytes,2,opt,name=Path,proto3" json:"Path,omitempty"`
+	// Time of the operation, in nanoseconds since the Unix epoch
+	Time int64 `protobuf:"varint,1,opt,name=Time,proto3" json:"Time,omitempty"`
+	// The operation (a Reset op records a call to the sink's Reset)
+	Op *OpItem `protobuf:"bytes,2,opt,name=Op,proto3" json:"Op,omitempty"`
 }
 
-func (x *Ref) Reset() {
-	*x = Ref{}
+func (x *RecordedOp) Reset() {
+	*x = RecordedOp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[3]
+		mi := &file_api_localnetv1_services_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Ref) String() string {
+func (x *RecordedOp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Ref) ProtoMessage() {}
+func (*RecordedOp) ProtoMessage() {}
 
-func (x *Ref) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[3]
+func (x *RecordedOp) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -378,51 +623,54 @@ func (x *Ref) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Ref.ProtoReflect.Descriptor instead.
-func (*Ref) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use RecordedOp.ProtoReflect.Descriptor instead.
+func (*RecordedOp) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Ref) GetSet() Set {
+func (x *RecordedOp) GetTime() int64 {
 	if x != nil {
-		return x.Set
+		return x.Time
 	}
-	return Set_UnknownSet
+	return 0
 }
 
-func (x *Ref) GetPath() string {
+func (x *RecordedOp) GetOp() *OpItem {
 	if x != nil {
-		return x.Path
+		return x.Op
 	}
-	return ""
+	return nil
 }
 
-type Value struct {
+// StoreSnapshot is a copy of a server's global store, to restart from the last known state.
+type StoreSnapshot struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ref   *Ref   `protobuf:"bytes,1,opt,name=Ref,proto3" json:"Ref,omitempty"`
-	Bytes []byte `protobuf:"bytes,2,opt,name=Bytes,proto3" json:"Bytes,omitempty"`
+	// Sets that were synced when the snapshot was taken
+	SyncedSets []Set `protobuf:"varint,1,rep,packed,name=SyncedSets,proto3,enum=localnetv1.Set" json:"SyncedSets,omitempty"`
+	// Values of the store (each value's Ref.Path is its path in the store)
+	Values []*Value `protobuf:"bytes,2,rep,name=Values,proto3" json:"Values,omitempty"`
 }
 
-func (x *Value) Reset() {
-	*x = Value{}
+func (x *StoreSnapshot) Reset() {
+	*x = StoreSnapshot{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[4]
+		mi := &file_api_localnetv1_services_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Value) String() string {
+func (x *StoreSnapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Value) ProtoMessage() {}
+func (*StoreSnapshot) ProtoMessage() {}
 
-func (x *Value) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[4]
+func (x *StoreSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -433,65 +681,51 @@ func (x *Value) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Value.ProtoReflect.Descriptor instead.
-func (*Value) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use StoreSnapshot.ProtoReflect.Descriptor instead.
+func (*StoreSnapshot) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *Value) GetRef() *Ref {
+func (x *StoreSnapshot) GetSyncedSets() []Set {
 	if x != nil {
-		return x.Ref
+		return x.SyncedSets
 	}
 	return nil
 }
 
-func (x *Value) GetBytes() []byte {
+func (x *StoreSnapshot) GetValues() []*Value {
 	if x != nil {
-		return x.Bytes
+		return x.Values
 	}
 	return nil
 }
 
-type Service struct {
+type Ref struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Namespace   string            `protobuf:"bytes,1,opt,name=Namespace,proto3" json:"Namespace,omitempty"`
-	Name        string            `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
-	Type        string            `protobuf:"bytes,3,opt,name=Type,proto3" json:"Type,omitempty"`
-	Labels      map[string]string `protobuf:"bytes,8,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Annotations map[string]string `protobuf:"bytes,9,rep,name=Annotations,proto3" json:"Annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	IPs         *ServiceIPs       `protobuf:"bytes,4,opt,name=IPs,proto3" json:"IPs,omitempty"`
-	IPFilters   []*IPFilter       `protobuf:"bytes,10,rep,name=IPFilters,proto3" json:"IPFilters,omitempty"`
-	// true if the service maps the whole IP, not just individual ports.
-	MapIP bool `protobuf:"varint,5,opt,name=MapIP,proto3" json:"MapIP,omitempty"`
-	// Individual ports mapped for the this service
-	Ports                  []*PortMapping `protobuf:"bytes,6,rep,name=Ports,proto3" json:"Ports,omitempty"`
-	ExternalTrafficToLocal bool           `protobuf:"varint,7,opt,name=ExternalTrafficToLocal,proto3" json:"ExternalTrafficToLocal,omitempty"`
-	// Types that are assignable to SessionAffinity:
-	//	*Service_ClientIP
-	SessionAffinity        isService_SessionAffinity `protobuf_oneof:"SessionAffinity"`
-	InternalTrafficToLocal bool                      `protobuf:"varint,12,opt,name=InternalTrafficToLocal,proto3" json:"InternalTrafficToLocal,omitempty"`
+	Set  Set    `protobuf:"varint,1,opt,name=Set,proto3,enum=localnetv1.Set" json:"Set,omitempty"`
+	Path string `protobuf:"bytes,2,opt,name=Path,proto3" json:"Path,omitempty"`
 }
 
-func (x *Service) Reset() {
-	*x = Service{}
+func (x *Ref) Reset() {
+	*x = Ref{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[5]
+		mi := &file_api_localnetv1_services_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Service) String() string {
+func (x *Ref) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Service) ProtoMessage() {}
+func (*Ref) ProtoMessage() {}
 
-func (x *Service) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[5]
+func (x *Ref) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -502,12 +736,142 @@ func (x *Service) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Service.ProtoReflect.Descriptor instead.
-func (*Service) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Ref.ProtoReflect.Descriptor instead.
+func (*Ref) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Service) GetNamespace() string {
+func (x *Ref) GetSet() Set {
+	if x != nil {
+		return x.Set
+	}
+	return Set_UnknownSet
+}
+
+func (x *Ref) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type Value struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ref   *Ref   `protobuf:"bytes,1,opt,name=Ref,proto3" json:"Ref,omitempty"`
+	Bytes []byte `protobuf:"bytes,2,opt,name=Bytes,proto3" json:"Bytes,omitempty"`
+}
+
+func (x *Value) Reset() {
+	*x = Value{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_localnetv1_services_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Value) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value) ProtoMessage() {}
+
+func (x *Value) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value.ProtoReflect.Descriptor instead.
+func (*Value) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Value) GetRef() *Ref {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+func (x *Value) GetBytes() []byte {
+	if x != nil {
+		return x.Bytes
+	}
+	return nil
+}
+
+type Service struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespace   string            `protobuf:"bytes,1,opt,name=Namespace,proto3" json:"Namespace,omitempty"`
+	Name        string            `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Type        string            `protobuf:"bytes,3,opt,name=Type,proto3" json:"Type,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,8,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations map[string]string `protobuf:"bytes,9,rep,name=Annotations,proto3" json:"Annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	IPs         *ServiceIPs       `protobuf:"bytes,4,opt,name=IPs,proto3" json:"IPs,omitempty"`
+	IPFilters   []*IPFilter       `protobuf:"bytes,10,rep,name=IPFilters,proto3" json:"IPFilters,omitempty"`
+	// true if the service maps the whole IP, not just individual ports.
+	MapIP bool `protobuf:"varint,5,opt,name=MapIP,proto3" json:"MapIP,omitempty"`
+	// Individual ports mapped for the this service
+	Ports                  []*PortMapping `protobuf:"bytes,6,rep,name=Ports,proto3" json:"Ports,omitempty"`
+	ExternalTrafficToLocal bool           `protobuf:"varint,7,opt,name=ExternalTrafficToLocal,proto3" json:"ExternalTrafficToLocal,omitempty"`
+	// Types that are assignable to SessionAffinity:
+	//	*Service_ClientIP
+	SessionAffinity        isService_SessionAffinity `protobuf_oneof:"SessionAffinity"`
+	InternalTrafficToLocal bool                      `protobuf:"varint,12,opt,name=InternalTrafficToLocal,proto3" json:"InternalTrafficToLocal,omitempty"`
+	// the target hostname of an ExternalName service
+	ExternalName string `protobuf:"bytes,13,opt,name=ExternalName,proto3" json:"ExternalName,omitempty"`
+	// the IP family policy (SingleStack, PreferDualStack or RequireDualStack)
+	IPFamilyPolicy string `protobuf:"bytes,14,opt,name=IPFamilyPolicy,proto3" json:"IPFamilyPolicy,omitempty"`
+	// the IP families (IPv4, IPv6) of the service, the first one being the primary family
+	IPFamilies []string `protobuf:"bytes,15,rep,name=IPFamilies,proto3" json:"IPFamilies,omitempty"`
+}
+
+func (x *Service) Reset() {
+	*x = Service{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_localnetv1_services_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Service) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Service) ProtoMessage() {}
+
+func (x *Service) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Service.ProtoReflect.Descriptor instead.
+func (*Service) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Service) GetNamespace() string {
 	if x != nil {
 		return x.Namespace
 	}
@@ -598,6 +962,27 @@ func (x *Service) GetInternalTrafficToLocal() bool {
 	return false
 }
 
+func (x *Service) GetExternalName() string {
+	if x != nil {
+		return x.ExternalName
+	}
+	return ""
+}
+
+func (x *Service) GetIPFamilyPolicy() string {
+	if x != nil {
+		return x.IPFamilyPolicy
+	}
+	return ""
+}
+
+func (x *Service) GetIPFamilies() []string {
+	if x != nil {
+		return x.IPFamilies
+	}
+	return nil
+}
+
 type isService_SessionAffinity interface {
 	isService_SessionAffinity()
 }
@@ -622,7 +1007,7 @@ type IPFilter struct {
 func (x *IPFilter) Reset() {
 	*x = IPFilter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[6]
+		mi := &file_api_localnetv1_services_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -635,7 +1020,7 @@ func (x *IPFilter) String() string {
 func (*IPFilter) ProtoMessage() {}
 
 func (x *IPFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[6]
+	mi := &file_api_localnetv1_services_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -648,7 +1033,7 @@ func (x *IPFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IPFilter.ProtoReflect.Descriptor instead.
 func (*IPFilter) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{6}
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *IPFilter) GetTargetIPs() *IPSet {
@@ -679,7 +1064,7 @@ type ServiceIPs struct {
 func (x *ServiceIPs) Reset() {
 	*x = ServiceIPs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[7]
+		mi := &file_api_localnetv1_services_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -692,7 +1077,7 @@ func (x *ServiceIPs) String() string {
 func (*ServiceIPs) ProtoMessage() {}
 
 func (x *ServiceIPs) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[7]
+	mi := &file_api_localnetv1_services_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -705,7 +1090,7 @@ func (x *ServiceIPs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceIPs.ProtoReflect.Descriptor instead.
 func (*ServiceIPs) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{7}
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ServiceIPs) GetClusterIPs() *IPSet {
@@ -745,12 +1130,23 @@ type Endpoint struct {
 	IPs           *IPSet           `protobuf:"bytes,2,opt,name=IPs,proto3" json:"IPs,omitempty"`
 	Local         bool             `protobuf:"varint,3,opt,name=Local,proto3" json:"Local,omitempty"`
 	PortOverrides map[string]int32 `protobuf:"bytes,4,rep,name=PortOverrides,proto3" json:"PortOverrides,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// true if the endpoint is terminating; such endpoints are only selected
+	// when no ready endpoint is left (and they're still serving).
+	Terminating bool `protobuf:"varint,5,opt,name=Terminating,proto3" json:"Terminating,omitempty"`
+	// true if the endpoint is not eligible for internal traffic (to cluster
+	// IPs); inverted so endpoints from servers not setting it stay eligible.
+	NotInternal bool `protobuf:"varint,6,opt,name=NotInternal,proto3" json:"NotInternal,omitempty"`
+	// true if the endpoint is not eligible for external traffic (to node
+	// ports, external IPs and load-balancer IPs); inverted like NotInternal.
+	NotExternal bool `protobuf:"varint,7,opt,name=NotExternal,proto3" json:"NotExternal,omitempty"`
+	// relative weight of the endpoint in the load-balancing (0 means the default weight of 1)
+	Weight int32 `protobuf:"varint,8,opt,name=Weight,proto3" json:"Weight,omitempty"`
 }
 
 func (x *Endpoint) Reset() {
 	*x = Endpoint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[8]
+		mi := &file_api_localnetv1_services_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -763,7 +1159,7 @@ func (x *Endpoint) String() string {
 func (*Endpoint) ProtoMessage() {}
 
 func (x *Endpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[8]
+	mi := &file_api_localnetv1_services_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -776,7 +1172,7 @@ func (x *Endpoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Endpoint.ProtoReflect.Descriptor instead.
 func (*Endpoint) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{8}
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Endpoint) GetHostname() string {
@@ -807,6 +1203,34 @@ func (x *Endpoint) GetPortOverrides() map[string]int32 {
 	return nil
 }
 
+func (x *Endpoint) GetTerminating() bool {
+	if x != nil {
+		return x.Terminating
+	}
+	return false
+}
+
+func (x *Endpoint) GetNotInternal() bool {
+	if x != nil {
+		return x.NotInternal
+	}
+	return false
+}
+
+func (x *Endpoint) GetNotExternal() bool {
+	if x != nil {
+		return x.NotExternal
+	}
+	return false
+}
+
+func (x *Endpoint) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
 type IPSet struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -819,7 +1243,7 @@ type IPSet struct {
 func (x *IPSet) Reset() {
 	*x = IPSet{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[9]
+		mi := &file_api_localnetv1_services_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -832,7 +1256,7 @@ func (x *IPSet) String() string {
 func (*IPSet) ProtoMessage() {}
 
 func (x *IPSet) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[9]
+	mi := &file_api_localnetv1_services_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -845,7 +1269,7 @@ func (x *IPSet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IPSet.ProtoReflect.Descriptor instead.
 func (*IPSet) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{9}
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *IPSet) GetV4() []string {
@@ -875,7 +1299,7 @@ type Port struct {
 func (x *Port) Reset() {
 	*x = Port{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[10]
+		mi := &file_api_localnetv1_services_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -888,7 +1312,7 @@ func (x *Port) String() string {
 func (*Port) ProtoMessage() {}
 
 func (x *Port) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[10]
+	mi := &file_api_localnetv1_services_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -901,7 +1325,7 @@ func (x *Port) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Port.ProtoReflect.Descriptor instead.
 func (*Port) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{10}
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Port) GetName() string {
@@ -930,31 +1354,706 @@ type PortMapping struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name           string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
-	Protocol       Protocol `protobuf:"varint,2,opt,name=Protocol,proto3,enum=localnetv1.Protocol" json:"Protocol,omitempty"`
-	Port           int32    `protobuf:"varint,3,opt,name=Port,proto3" json:"Port,omitempty"`
-	NodePort       int32    `protobuf:"varint,4,opt,name=NodePort,proto3" json:"NodePort,omitempty"`
-	TargetPort     int32    `protobuf:"varint,5,opt,name=TargetPort,proto3" json:"TargetPort,omitempty"`
-	TargetPortName string   `protobuf:"bytes,6,opt,name=TargetPortName,proto3" json:"TargetPortName,omitempty"`
+	Name           string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Protocol       Protocol `protobuf:"varint,2,opt,name=Protocol,proto3,enum=localnetv1.Protocol" json:"Protocol,omitempty"`
+	Port           int32    `protobuf:"varint,3,opt,name=Port,proto3" json:"Port,omitempty"`
+	NodePort       int32    `protobuf:"varint,4,opt,name=NodePort,proto3" json:"NodePort,omitempty"`
+	TargetPort     int32    `protobuf:"varint,5,opt,name=TargetPort,proto3" json:"TargetPort,omitempty"`
+	TargetPortName string   `protobuf:"bytes,6,opt,name=TargetPortName,proto3" json:"TargetPortName,omitempty"`
+	// the application protocol of this port (ie: "http", "kubernetes.io/h2c")
+	AppProtocol string `protobuf:"bytes,7,opt,name=AppProtocol,proto3" json:"AppProtocol,omitempty"`
+}
+
+func (x *PortMapping) Reset() {
+	*x = PortMapping{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_localnetv1_services_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PortMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortMapping) ProtoMessage() {}
+
+func (x *PortMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortMapping.ProtoReflect.Descriptor instead.
+func (*PortMapping) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PortMapping) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PortMapping) GetProtocol() Protocol {
+	if x != nil {
+		return x.Protocol
+	}
+	return Protocol_UnknownProtocol
+}
+
+func (x *PortMapping) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *PortMapping) GetNodePort() int32 {
+	if x != nil {
+		return x.NodePort
+	}
+	return 0
+}
+
+func (x *PortMapping) GetTargetPort() int32 {
+	if x != nil {
+		return x.TargetPort
+	}
+	return 0
+}
+
+func (x *PortMapping) GetTargetPortName() string {
+	if x != nil {
+		return x.TargetPortName
+	}
+	return ""
+}
+
+func (x *PortMapping) GetAppProtocol() string {
+	if x != nil {
+		return x.AppProtocol
+	}
+	return ""
+}
+
+type ClientIPAffinity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TimeoutSeconds int32 `protobuf:"varint,1,opt,name=TimeoutSeconds,proto3" json:"TimeoutSeconds,omitempty"`
+}
+
+func (x *ClientIPAffinity) Reset() {
+	*x = ClientIPAffinity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_localnetv1_services_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientIPAffinity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientIPAffinity) ProtoMessage() {}
+
+func (x *ClientIPAffinity) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientIPAffinity.ProtoReflect.Descriptor instead.
+func (*ClientIPAffinity) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ClientIPAffinity) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+type ServiceInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash    uint64   `protobuf:"varint,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Service *Service `protobuf:"bytes,2,opt,name=Service,proto3" json:"Service,omitempty"`
+}
+
+func (x *ServiceInfo) Reset() {
+	*x = ServiceInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_localnetv1_services_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServiceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceInfo) ProtoMessage() {}
+
+func (x *ServiceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceInfo.ProtoReflect.Descriptor instead.
+func (*ServiceInfo) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ServiceInfo) GetHash() uint64 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+func (x *ServiceInfo) GetService() *Service {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+type EndpointInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash        uint64              `protobuf:"varint,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Namespace   string              `protobuf:"bytes,2,opt,name=Namespace,proto3" json:"Namespace,omitempty"`
+	SourceName  string              `protobuf:"bytes,3,opt,name=SourceName,proto3" json:"SourceName,omitempty"`
+	ServiceName string              `protobuf:"bytes,4,opt,name=ServiceName,proto3" json:"ServiceName,omitempty"`
+	PodName     string              `protobuf:"bytes,8,opt,name=PodName,proto3" json:"PodName,omitempty"`
+	Endpoint    *Endpoint           `protobuf:"bytes,6,opt,name=Endpoint,proto3" json:"Endpoint,omitempty"`
+	Conditions  *EndpointConditions `protobuf:"bytes,7,opt,name=Conditions,proto3" json:"Conditions,omitempty"`
+	Topology    *TopologyInfo       `protobuf:"bytes,9,opt,name=Topology,proto3" json:"Topology,omitempty"`
+	Hints       *TopologyHints      `protobuf:"bytes,10,opt,name=Hints,proto3" json:"Hints,omitempty"`
+}
+
+func (x *EndpointInfo) Reset() {
+	*x = EndpointInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_localnetv1_services_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EndpointInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndpointInfo) ProtoMessage() {}
+
+func (x *EndpointInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndpointInfo.ProtoReflect.Descriptor instead.
+func (*EndpointInfo) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *EndpointInfo) GetHash() uint64 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+func (x *EndpointInfo) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *EndpointInfo) GetSourceName() string {
+	if x != nil {
+		return x.SourceName
+	}
+	return ""
+}
+
+func (x *EndpointInfo) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *EndpointInfo) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+func (x *EndpointInfo) GetEndpoint() *Endpoint {
+	if x != nil {
+		return x.Endpoint
+	}
+	return nil
+}
+
+func (x *EndpointInfo) GetConditions() *EndpointConditions {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *EndpointInfo) GetTopology() *TopologyInfo {
+	if x != nil {
+		return x.Topology
+	}
+	return nil
+}
+
+func (x *EndpointInfo) GetHints() *TopologyHints {
+	if x != nil {
+		return x.Hints
+	}
+	return nil
+}
+
+type EndpointConditions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ready bool `protobuf:"varint,1,opt,name=Ready,proto3" json:"Ready,omitempty"`
+	// Serving is the same as Ready, but also true while the endpoint is terminating
+	Serving     bool `protobuf:"varint,2,opt,name=Serving,proto3" json:"Serving,omitempty"`
+	Terminating bool `protobuf:"varint,3,opt,name=Terminating,proto3" json:"Terminating,omitempty"`
+}
+
+func (x *EndpointConditions) Reset() {
+	*x = EndpointConditions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_localnetv1_services_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EndpointConditions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndpointConditions) ProtoMessage() {}
+
+func (x *EndpointConditions) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndpointConditions.ProtoReflect.Descriptor instead.
+func (*EndpointConditions) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *EndpointConditions) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *EndpointConditions) GetServing() bool {
+	if x != nil {
+		return x.Serving
+	}
+	return false
+}
+
+func (x *EndpointConditions) GetTerminating() bool {
+	if x != nil {
+		return x.Terminating
+	}
+	return false
+}
+
+type TopologyInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Node string `protobuf:"bytes,1,opt,name=Node,proto3" json:"Node,omitempty"`
+	Zone string `protobuf:"bytes,2,opt,name=Zone,proto3" json:"Zone,omitempty"`
+}
+
+func (x *TopologyInfo) Reset() {
+	*x = TopologyInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_localnetv1_services_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopologyInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologyInfo) ProtoMessage() {}
+
+func (x *TopologyInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologyInfo.ProtoReflect.Descriptor instead.
+func (*TopologyInfo) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TopologyInfo) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *TopologyInfo) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+type TopologyHints struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Zones []string `protobuf:"bytes,1,rep,name=Zones,proto3" json:"Zones,omitempty"`
+}
+
+func (x *TopologyHints) Reset() {
+	*x = TopologyHints{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_localnetv1_services_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopologyHints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologyHints) ProtoMessage() {}
+
+func (x *TopologyHints) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologyHints.ProtoReflect.Descriptor instead.
+func (*TopologyHints) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TopologyHints) GetZones() []string {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+type NodeInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash uint64 `protobuf:"varint,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Node *Node  `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
+}
+
+func (x *NodeInfo) Reset() {
+	*x = NodeInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_localnetv1_services_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeInfo) ProtoMessage() {}
+
+func (x *NodeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeInfo.ProtoReflect.Descriptor instead.
+func (*NodeInfo) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *NodeInfo) GetHash() uint64 {
+	if x != nil {
+		return x.Hash
+	}
+	return 0
+}
+
+func (x *NodeInfo) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+type Node struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string            `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Topology    *TopologyInfo     `protobuf:"bytes,4,opt,name=Topology,proto3" json:"Topology,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,2,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Annotations map[string]string `protobuf:"bytes,3,rep,name=Annotations,proto3" json:"Annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Addresses of the node, as reported in its status
+	Addresses []*NodeAddress `protobuf:"bytes,5,rep,name=Addresses,proto3" json:"Addresses,omitempty"`
+	// PodCIDRs assigned to the node
+	PodCIDRs []string `protobuf:"bytes,6,rep,name=PodCIDRs,proto3" json:"PodCIDRs,omitempty"`
+}
+
+func (x *Node) Reset() {
+	*x = Node{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_localnetv1_services_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Node) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Node) ProtoMessage() {}
+
+func (x *Node) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Node) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Node) GetTopology() *TopologyInfo {
+	if x != nil {
+		return x.Topology
+	}
+	return nil
+}
+
+func (x *Node) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Node) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *Node) GetAddresses() []*NodeAddress {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *Node) GetPodCIDRs() []string {
+	if x != nil {
+		return x.PodCIDRs
+	}
+	return nil
+}
+
+type NodeAddress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type of the address (Hostname, ExternalIP, InternalIP, ExternalDNS or InternalDNS)
+	Type    string `protobuf:"bytes,1,opt,name=Type,proto3" json:"Type,omitempty"`
+	Address string `protobuf:"bytes,2,opt,name=Address,proto3" json:"Address,omitempty"`
+}
+
+func (x *NodeAddress) Reset() {
+	*x = NodeAddress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_localnetv1_services_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeAddress) ProtoMessage() {}
+
+func (x *NodeAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeAddress.ProtoReflect.Descriptor instead.
+func (*NodeAddress) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *NodeAddress) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *NodeAddress) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type GlobalWatchReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// LastRevision is the last revision fully received by the requester (see WatchReq.LastRevision)
+	LastRevision *Revision `protobuf:"bytes,1,opt,name=LastRevision,proto3" json:"LastRevision,omitempty"`
+	// Filter on the services (and their endpoints) to watch (all if not set)
+	Filter *WatchFilter `protobuf:"bytes,2,opt,name=Filter,proto3" json:"Filter,omitempty"`
+	// Sets to watch, in GlobalServiceInfos, GlobalEndpointInfos and GlobalNodeInfos (all if empty)
+	Sets []Set `protobuf:"varint,3,rep,packed,name=Sets,proto3,enum=localnetv1.Set" json:"Sets,omitempty"`
 }
 
-func (x *PortMapping) Reset() {
-	*x = PortMapping{}
+func (x *GlobalWatchReq) Reset() {
+	*x = GlobalWatchReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[11]
+		mi := &file_api_localnetv1_services_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PortMapping) String() string {
+func (x *GlobalWatchReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PortMapping) ProtoMessage() {}
+func (*GlobalWatchReq) ProtoMessage() {}
 
-func (x *PortMapping) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[11]
+func (x *GlobalWatchReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -965,78 +2064,55 @@ func (x *PortMapping) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PortMapping.ProtoReflect.Descriptor instead.
-func (*PortMapping) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{11}
-}
-
-func (x *PortMapping) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *PortMapping) GetProtocol() Protocol {
-	if x != nil {
-		return x.Protocol
-	}
-	return Protocol_UnknownProtocol
-}
-
-func (x *PortMapping) GetPort() int32 {
-	if x != nil {
-		return x.Port
-	}
-	return 0
+// Deprecated: Use GlobalWatchReq.ProtoReflect.Descriptor instead.
+func (*GlobalWatchReq) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *PortMapping) GetNodePort() int32 {
+func (x *GlobalWatchReq) GetLastRevision() *Revision {
 	if x != nil {
-		return x.NodePort
+		return x.LastRevision
 	}
-	return 0
+	return nil
 }
 
-func (x *PortMapping) GetTargetPort() int32 {
+func (x *GlobalWatchReq) GetFilter() *WatchFilter {
 	if x != nil {
-		return x.TargetPort
+		return x.Filter
 	}
-	return 0
+	return nil
 }
 
-func (x *PortMapping) GetTargetPortName() string {
+func (x *GlobalWatchReq) GetSets() []Set {
 	if x != nil {
-		return x.TargetPortName
+		return x.Sets
 	}
-	return ""
+	return nil
 }
 
-type ClientIPAffinity struct {
+type ListWatchesReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	TimeoutSeconds int32 `protobuf:"varint,1,opt,name=TimeoutSeconds,proto3" json:"TimeoutSeconds,omitempty"`
 }
 
-func (x *ClientIPAffinity) Reset() {
-	*x = ClientIPAffinity{}
+func (x *ListWatchesReq) Reset() {
+	*x = ListWatchesReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[12]
+		mi := &file_api_localnetv1_services_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientIPAffinity) String() string {
+func (x *ListWatchesReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientIPAffinity) ProtoMessage() {}
+func (*ListWatchesReq) ProtoMessage() {}
 
-func (x *ClientIPAffinity) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[12]
+func (x *ListWatchesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1047,44 +2123,38 @@ func (x *ClientIPAffinity) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientIPAffinity.ProtoReflect.Descriptor instead.
-func (*ClientIPAffinity) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{12}
-}
-
-func (x *ClientIPAffinity) GetTimeoutSeconds() int32 {
-	if x != nil {
-		return x.TimeoutSeconds
-	}
-	return 0
+// Deprecated: Use ListWatchesReq.ProtoReflect.Descriptor instead.
+func (*ListWatchesReq) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{27}
 }
 
-type ServiceInfo struct {
+type ListWatchesRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Hash    uint64   `protobuf:"varint,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
-	Service *Service `protobuf:"bytes,2,opt,name=Service,proto3" json:"Service,omitempty"`
+	// Revision of the server's store
+	Revision *Revision    `protobuf:"bytes,1,opt,name=Revision,proto3" json:"Revision,omitempty"`
+	Watches  []*WatchInfo `protobuf:"bytes,2,rep,name=Watches,proto3" json:"Watches,omitempty"`
 }
 
-func (x *ServiceInfo) Reset() {
-	*x = ServiceInfo{}
+func (x *ListWatchesRes) Reset() {
+	*x = ListWatchesRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[13]
+		mi := &file_api_localnetv1_services_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ServiceInfo) String() string {
+func (x *ListWatchesRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServiceInfo) ProtoMessage() {}
+func (*ListWatchesRes) ProtoMessage() {}
 
-func (x *ServiceInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[13]
+func (x *ListWatchesRes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1095,58 +2165,69 @@ func (x *ServiceInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServiceInfo.ProtoReflect.Descriptor instead.
-func (*ServiceInfo) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ListWatchesRes.ProtoReflect.Descriptor instead.
+func (*ListWatchesRes) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *ServiceInfo) GetHash() uint64 {
+func (x *ListWatchesRes) GetRevision() *Revision {
 	if x != nil {
-		return x.Hash
+		return x.Revision
 	}
-	return 0
+	return nil
 }
 
-func (x *ServiceInfo) GetService() *Service {
+func (x *ListWatchesRes) GetWatches() []*WatchInfo {
 	if x != nil {
-		return x.Service
+		return x.Watches
 	}
 	return nil
 }
 
-type EndpointInfo struct {
+type WatchInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Hash        uint64              `protobuf:"varint,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
-	Namespace   string              `protobuf:"bytes,2,opt,name=Namespace,proto3" json:"Namespace,omitempty"`
-	SourceName  string              `protobuf:"bytes,3,opt,name=SourceName,proto3" json:"SourceName,omitempty"`
-	ServiceName string              `protobuf:"bytes,4,opt,name=ServiceName,proto3" json:"ServiceName,omitempty"`
-	PodName     string              `protobuf:"bytes,8,opt,name=PodName,proto3" json:"PodName,omitempty"`
-	Endpoint    *Endpoint           `protobuf:"bytes,6,opt,name=Endpoint,proto3" json:"Endpoint,omitempty"`
-	Conditions  *EndpointConditions `protobuf:"bytes,7,opt,name=Conditions,proto3" json:"Conditions,omitempty"`
-	Topology    *TopologyInfo       `protobuf:"bytes,9,opt,name=Topology,proto3" json:"Topology,omitempty"`
-	Hints       *TopologyHints      `protobuf:"bytes,10,opt,name=Hints,proto3" json:"Hints,omitempty"`
-}
-
-func (x *EndpointInfo) Reset() {
-	*x = EndpointInfo{}
+	ID uint64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// API watched (local or global)
+	API string `protobuf:"bytes,2,opt,name=API,proto3" json:"API,omitempty"`
+	// Peer address of the watcher
+	Peer string `protobuf:"bytes,3,opt,name=Peer,proto3" json:"Peer,omitempty"`
+	// NodeName requested by the watcher (local API only)
+	NodeName string `protobuf:"bytes,4,opt,name=NodeName,proto3" json:"NodeName,omitempty"`
+	// StartTime of the watch, in nanoseconds since the Unix epoch
+	StartTime int64 `protobuf:"varint,5,opt,name=StartTime,proto3" json:"StartTime,omitempty"`
+	// LastRevision is the revision sent with the last sync (not set until the first sync)
+	LastRevision *Revision `protobuf:"bytes,6,opt,name=LastRevision,proto3" json:"LastRevision,omitempty"`
+	// LastSyncTime is the time of the last sync, in nanoseconds since the Unix epoch (0 until the first sync)
+	LastSyncTime int64 `protobuf:"varint,7,opt,name=LastSyncTime,proto3" json:"LastSyncTime,omitempty"`
+	// RevisionsBehind is the number of store revisions not sent yet
+	RevisionsBehind uint64 `protobuf:"varint,8,opt,name=RevisionsBehind,proto3" json:"RevisionsBehind,omitempty"`
+	// ChangesBehind is the number of store changes since the last sync, some of them may not concern
+	// the watcher (-1 if unknown, ie: not synced yet or beyond the store's journal)
+	ChangesBehind int64 `protobuf:"varint,9,opt,name=ChangesBehind,proto3" json:"ChangesBehind,omitempty"`
+	// LastApply is the last apply status reported by the watcher (not set if none)
+	LastApply *ApplyStatus `protobuf:"bytes,10,opt,name=LastApply,proto3" json:"LastApply,omitempty"`
+}
+
+func (x *WatchInfo) Reset() {
+	*x = WatchInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[14]
+		mi := &file_api_localnetv1_services_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EndpointInfo) String() string {
+func (x *WatchInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EndpointInfo) ProtoMessage() {}
+func (*WatchInfo) ProtoMessage() {}
 
-func (x *EndpointInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[14]
+func (x *WatchInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1157,147 +2238,106 @@ func (x *EndpointInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EndpointInfo.ProtoReflect.Descriptor instead.
-func (*EndpointInfo) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use WatchInfo.ProtoReflect.Descriptor instead.
+func (*WatchInfo) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *EndpointInfo) GetHash() uint64 {
+func (x *WatchInfo) GetID() uint64 {
 	if x != nil {
-		return x.Hash
+		return x.ID
 	}
 	return 0
 }
 
-func (x *EndpointInfo) GetNamespace() string {
+func (x *WatchInfo) GetAPI() string {
 	if x != nil {
-		return x.Namespace
+		return x.API
 	}
 	return ""
 }
 
-func (x *EndpointInfo) GetSourceName() string {
+func (x *WatchInfo) GetPeer() string {
 	if x != nil {
-		return x.SourceName
+		return x.Peer
 	}
 	return ""
 }
 
-func (x *EndpointInfo) GetServiceName() string {
+func (x *WatchInfo) GetNodeName() string {
 	if x != nil {
-		return x.ServiceName
+		return x.NodeName
 	}
 	return ""
 }
 
-func (x *EndpointInfo) GetPodName() string {
+func (x *WatchInfo) GetStartTime() int64 {
 	if x != nil {
-		return x.PodName
+		return x.StartTime
 	}
-	return ""
+	return 0
 }
 
-func (x *EndpointInfo) GetEndpoint() *Endpoint {
+func (x *WatchInfo) GetLastRevision() *Revision {
 	if x != nil {
-		return x.Endpoint
+		return x.LastRevision
 	}
 	return nil
 }
 
-func (x *EndpointInfo) GetConditions() *EndpointConditions {
+func (x *WatchInfo) GetLastSyncTime() int64 {
 	if x != nil {
-		return x.Conditions
+		return x.LastSyncTime
 	}
-	return nil
+	return 0
 }
 
-func (x *EndpointInfo) GetTopology() *TopologyInfo {
+func (x *WatchInfo) GetRevisionsBehind() uint64 {
 	if x != nil {
-		return x.Topology
+		return x.RevisionsBehind
 	}
-	return nil
+	return 0
 }
 
-func (x *EndpointInfo) GetHints() *TopologyHints {
+func (x *WatchInfo) GetChangesBehind() int64 {
 	if x != nil {
-		return x.Hints
-	}
-	return nil
-}
-
-type EndpointConditions struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Ready bool `protobuf:"varint,1,opt,name=Ready,proto3" json:"Ready,omitempty"`
-}
-
-func (x *EndpointConditions) Reset() {
-	*x = EndpointConditions{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[15]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+		return x.ChangesBehind
 	}
+	return 0
 }
 
-func (x *EndpointConditions) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*EndpointConditions) ProtoMessage() {}
-
-func (x *EndpointConditions) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[15]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use EndpointConditions.ProtoReflect.Descriptor instead.
-func (*EndpointConditions) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{15}
-}
-
-func (x *EndpointConditions) GetReady() bool {
+func (x *WatchInfo) GetLastApply() *ApplyStatus {
 	if x != nil {
-		return x.Ready
+		return x.LastApply
 	}
-	return false
+	return nil
 }
 
-type TopologyInfo struct {
+type ResetWatchReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Node string `protobuf:"bytes,1,opt,name=Node,proto3" json:"Node,omitempty"`
-	Zone string `protobuf:"bytes,2,opt,name=Zone,proto3" json:"Zone,omitempty"`
+	ID uint64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
 }
 
-func (x *TopologyInfo) Reset() {
-	*x = TopologyInfo{}
+func (x *ResetWatchReq) Reset() {
+	*x = ResetWatchReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[16]
+		mi := &file_api_localnetv1_services_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TopologyInfo) String() string {
+func (x *ResetWatchReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TopologyInfo) ProtoMessage() {}
+func (*ResetWatchReq) ProtoMessage() {}
 
-func (x *TopologyInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[16]
+func (x *ResetWatchReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1308,50 +2348,41 @@ func (x *TopologyInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TopologyInfo.ProtoReflect.Descriptor instead.
-func (*TopologyInfo) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{16}
-}
-
-func (x *TopologyInfo) GetNode() string {
-	if x != nil {
-		return x.Node
-	}
-	return ""
+// Deprecated: Use ResetWatchReq.ProtoReflect.Descriptor instead.
+func (*ResetWatchReq) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *TopologyInfo) GetZone() string {
+func (x *ResetWatchReq) GetID() uint64 {
 	if x != nil {
-		return x.Zone
+		return x.ID
 	}
-	return ""
+	return 0
 }
 
-type TopologyHints struct {
+type ResetWatchRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Zones []string `protobuf:"bytes,1,rep,name=Zones,proto3" json:"Zones,omitempty"`
 }
 
-func (x *TopologyHints) Reset() {
-	*x = TopologyHints{}
+func (x *ResetWatchRes) Reset() {
+	*x = ResetWatchRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[17]
+		mi := &file_api_localnetv1_services_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TopologyHints) String() string {
+func (x *ResetWatchRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TopologyHints) ProtoMessage() {}
+func (*ResetWatchRes) ProtoMessage() {}
 
-func (x *TopologyHints) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[17]
+func (x *ResetWatchRes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1362,44 +2393,34 @@ func (x *TopologyHints) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TopologyHints.ProtoReflect.Descriptor instead.
-func (*TopologyHints) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{17}
-}
-
-func (x *TopologyHints) GetZones() []string {
-	if x != nil {
-		return x.Zones
-	}
-	return nil
+// Deprecated: Use ResetWatchRes.ProtoReflect.Descriptor instead.
+func (*ResetWatchRes) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{31}
 }
 
-type NodeInfo struct {
+type ListApplyStatusesReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Hash uint64 `protobuf:"varint,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
-	Node *Node  `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
 }
 
-func (x *NodeInfo) Reset() {
-	*x = NodeInfo{}
+func (x *ListApplyStatusesReq) Reset() {
+	*x = ListApplyStatusesReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[18]
+		mi := &file_api_localnetv1_services_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *NodeInfo) String() string {
+func (x *ListApplyStatusesReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NodeInfo) ProtoMessage() {}
+func (*ListApplyStatusesReq) ProtoMessage() {}
 
-func (x *NodeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[18]
+func (x *ListApplyStatusesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1407,56 +2428,39 @@ func (x *NodeInfo) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use NodeInfo.ProtoReflect.Descriptor instead.
-func (*NodeInfo) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{18}
-}
-
-func (x *NodeInfo) GetHash() uint64 {
-	if x != nil {
-		return x.Hash
-	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *NodeInfo) GetNode() *Node {
-	if x != nil {
-		return x.Node
-	}
-	return nil
+// Deprecated: Use ListApplyStatusesReq.ProtoReflect.Descriptor instead.
+func (*ListApplyStatusesReq) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{32}
 }
 
-type Node struct {
+type ListApplyStatusesRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name        string            `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
-	Topology    *TopologyInfo     `protobuf:"bytes,4,opt,name=Topology,proto3" json:"Topology,omitempty"`
-	Labels      map[string]string `protobuf:"bytes,2,rep,name=Labels,proto3" json:"Labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Annotations map[string]string `protobuf:"bytes,3,rep,name=Annotations,proto3" json:"Annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Nodes []*NodeApplyStatus `protobuf:"bytes,1,rep,name=Nodes,proto3" json:"Nodes,omitempty"`
 }
 
-func (x *Node) Reset() {
-	*x = Node{}
+func (x *ListApplyStatusesRes) Reset() {
+	*x = ListApplyStatusesRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[19]
+		mi := &file_api_localnetv1_services_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Node) String() string {
+func (x *ListApplyStatusesRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Node) ProtoMessage() {}
+func (*ListApplyStatusesRes) ProtoMessage() {}
 
-func (x *Node) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[19]
+func (x *ListApplyStatusesRes) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1467,62 +2471,49 @@ func (x *Node) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Node.ProtoReflect.Descriptor instead.
-func (*Node) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{19}
-}
-
-func (x *Node) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Node) GetTopology() *TopologyInfo {
-	if x != nil {
-		return x.Topology
-	}
-	return nil
-}
-
-func (x *Node) GetLabels() map[string]string {
-	if x != nil {
-		return x.Labels
-	}
-	return nil
+// Deprecated: Use ListApplyStatusesRes.ProtoReflect.Descriptor instead.
+func (*ListApplyStatusesRes) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *Node) GetAnnotations() map[string]string {
+func (x *ListApplyStatusesRes) GetNodes() []*NodeApplyStatus {
 	if x != nil {
-		return x.Annotations
+		return x.Nodes
 	}
 	return nil
 }
 
-type GlobalWatchReq struct {
+type NodeApplyStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	NodeName string `protobuf:"bytes,1,opt,name=NodeName,proto3" json:"NodeName,omitempty"`
+	// Status is the last apply status reported by the node
+	Status *ApplyStatus `protobuf:"bytes,2,opt,name=Status,proto3" json:"Status,omitempty"`
+	// Time of the report, in nanoseconds since the Unix epoch
+	Time int64 `protobuf:"varint,3,opt,name=Time,proto3" json:"Time,omitempty"`
+	// Failures is the number of consecutive failed applies reported by the node
+	Failures uint64 `protobuf:"varint,4,opt,name=Failures,proto3" json:"Failures,omitempty"`
 }
 
-func (x *GlobalWatchReq) Reset() {
-	*x = GlobalWatchReq{}
+func (x *NodeApplyStatus) Reset() {
+	*x = NodeApplyStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_localnetv1_services_proto_msgTypes[20]
+		mi := &file_api_localnetv1_services_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GlobalWatchReq) String() string {
+func (x *NodeApplyStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GlobalWatchReq) ProtoMessage() {}
+func (*NodeApplyStatus) ProtoMessage() {}
 
-func (x *GlobalWatchReq) ProtoReflect() protoreflect.Message {
-	mi := &file_api_localnetv1_services_proto_msgTypes[20]
+func (x *NodeApplyStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_localnetv1_services_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1533,9 +2524,37 @@ func (x *GlobalWatchReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GlobalWatchReq.ProtoReflect.Descriptor instead.
-func (*GlobalWatchReq) Descriptor() ([]byte, []int) {
-	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use NodeApplyStatus.ProtoReflect.Descriptor instead.
+func (*NodeApplyStatus) Descriptor() ([]byte, []int) {
+	return file_api_localnetv1_services_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *NodeApplyStatus) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *NodeApplyStatus) GetStatus() *ApplyStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *NodeApplyStatus) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *NodeApplyStatus) GetFailures() uint64 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
 }
 
 var File_api_localnetv1_services_proto protoreflect.FileDescriptor
@@ -1543,10 +2562,39 @@ var File_api_localnetv1_services_proto protoreflect.FileDescriptor
 var file_api_localnetv1_services_proto_rawDesc = []byte{
 	0x0a, 0x1d, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31,
 	0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x0a, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x22, 0x26, 0x0a, 0x08, 0x57,
-	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x4e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x4e,
-	0x61, 0x6d, 0x65, 0x22, 0xb8, 0x01, 0x0a, 0x06, 0x4f, 0x70, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x29,
+	0x0a, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x22, 0xcc, 0x01, 0x0a, 0x08,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4e, 0x6f, 0x64, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x0c, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x63,
+	0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x0c, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2f,
+	0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12,
+	0x39, 0x0a, 0x0b, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76,
+	0x31, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0b, 0x41,
+	0x70, 0x70, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x7d, 0x0a, 0x0b, 0x41, 0x70,
+	0x70, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x52, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x08, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x26, 0x0a, 0x0e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x46, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0xa7, 0x01, 0x0a, 0x0b, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x4e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x45, 0x78, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12,
+	0x22, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x73, 0x22, 0xea, 0x01, 0x0a, 0x06, 0x4f, 0x70, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x29,
 	0x0a, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c,
 	0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x4f,
 	0x70, 0x48, 0x00, 0x52, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x2b, 0x0a, 0x05, 0x52, 0x65, 0x73,
@@ -1557,206 +2605,327 @@ var file_api_localnetv1_services_proto_rawDesc = []byte{
 	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x03, 0x53, 0x65, 0x74, 0x12, 0x29, 0x0a,
 	0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
 	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x48, 0x00,
-	0x52, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x04, 0x0a, 0x02, 0x4f, 0x70, 0x22, 0x09,
-	0x0a, 0x07, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x4f, 0x70, 0x22, 0x3c, 0x0a, 0x03, 0x52, 0x65, 0x66,
-	0x12, 0x21, 0x0a, 0x03, 0x53, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e,
-	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x03,
-	0x53, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x50, 0x61, 0x74, 0x68, 0x22, 0x40, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x12, 0x21, 0x0a, 0x03, 0x52, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
-	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x52, 0x03,
-	0x52, 0x65, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x05, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0xad, 0x05, 0x0a, 0x07, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
-	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70,
-	0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x37, 0x0a, 0x06, 0x4c,
-	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c, 0x6f,
-	0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x4c, 0x61,
-	0x62, 0x65, 0x6c, 0x73, 0x12, 0x46, 0x0a, 0x0b, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x6f, 0x63, 0x61,
-	0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41,
-	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x0b, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x03,
-	0x49, 0x50, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x6f, 0x63, 0x61,
-	0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x50,
-	0x73, 0x52, 0x03, 0x49, 0x50, 0x73, 0x12, 0x32, 0x0a, 0x09, 0x49, 0x50, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x63, 0x61,
-	0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x49, 0x50, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
-	0x09, 0x49, 0x50, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x4d, 0x61,
-	0x70, 0x49, 0x50, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x4d, 0x61, 0x70, 0x49, 0x50,
-	0x12, 0x2d, 0x0a, 0x05, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x17, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x72,
-	0x74, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x52, 0x05, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12,
-	0x36, 0x0a, 0x16, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x54, 0x72, 0x61, 0x66, 0x66,
-	0x69, 0x63, 0x54, 0x6f, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x16, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63,
-	0x54, 0x6f, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x12, 0x3a, 0x0a, 0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x49, 0x50, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6c, 0x6f, 0x63, 0x61,
-	0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x50, 0x41,
-	0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x48, 0x00, 0x52, 0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x49, 0x50, 0x12, 0x36, 0x0a, 0x16, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x54,
-	0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x54, 0x6f, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x0c, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x16, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x54, 0x72, 0x61,
-	0x66, 0x66, 0x69, 0x63, 0x54, 0x6f, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x1a, 0x39, 0x0a, 0x0b, 0x4c,
-	0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x11, 0x0a, 0x0f, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x22, 0x5f, 0x0a, 0x08, 0x49, 0x50, 0x46,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x09, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49,
-	0x50, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
-	0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x49, 0x50, 0x53, 0x65, 0x74, 0x52, 0x09, 0x54, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x49, 0x50, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x53, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0xcd, 0x01, 0x0a, 0x0a, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x50, 0x73, 0x12, 0x31, 0x0a, 0x0a, 0x43, 0x6c, 0x75,
-	0x73, 0x74, 0x65, 0x72, 0x49, 0x50, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x52, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x52, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x63,
+	0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x08, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x04, 0x0a, 0x02, 0x4f, 0x70,
+	0x22, 0x32, 0x0a, 0x08, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x45, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x45, 0x70, 0x6f,
+	0x63, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x52, 0x65, 0x76, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x03, 0x52, 0x65, 0x76, 0x22, 0x09, 0x0a, 0x07, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x4f, 0x70, 0x22,
+	0x44, 0x0a, 0x0a, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x4f, 0x70, 0x12, 0x12, 0x0a,
+	0x04, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x22, 0x0a, 0x02, 0x4f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x49, 0x74, 0x65,
+	0x6d, 0x52, 0x02, 0x4f, 0x70, 0x22, 0x6b, 0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x2f, 0x0a, 0x0a, 0x53, 0x79, 0x6e, 0x63, 0x65, 0x64,
+	0x53, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x6c, 0x6f, 0x63,
+	0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x0a, 0x53, 0x79, 0x6e,
+	0x63, 0x65, 0x64, 0x53, 0x65, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x06, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e,
+	0x65, 0x74, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x22, 0x3c, 0x0a, 0x03, 0x52, 0x65, 0x66, 0x12, 0x21, 0x0a, 0x03, 0x53, 0x65, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65,
+	0x74, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x03, 0x53, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x74, 0x68,
+	0x22, 0x40, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x21, 0x0a, 0x03, 0x52, 0x65, 0x66,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65,
+	0x74, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x66, 0x52, 0x03, 0x52, 0x65, 0x66, 0x12, 0x14, 0x0a, 0x05,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x22, 0x99, 0x06, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x37, 0x0a, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x46, 0x0a,
+	0x0b, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x03, 0x49, 0x50, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x50, 0x73, 0x52, 0x03, 0x49, 0x50, 0x73, 0x12,
+	0x32, 0x0a, 0x09, 0x49, 0x50, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e,
+	0x49, 0x50, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x09, 0x49, 0x50, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x4d, 0x61, 0x70, 0x49, 0x50, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x4d, 0x61, 0x70, 0x49, 0x50, 0x12, 0x2d, 0x0a, 0x05, 0x50, 0x6f, 0x72,
+	0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e,
+	0x67, 0x52, 0x05, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x16, 0x45, 0x78, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x54, 0x6f, 0x4c, 0x6f, 0x63,
+	0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x54, 0x6f, 0x4c, 0x6f, 0x63, 0x61, 0x6c,
+	0x12, 0x3a, 0x0a, 0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x50, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x50, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79,
+	0x48, 0x00, 0x52, 0x08, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x50, 0x12, 0x36, 0x0a, 0x16,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x54,
+	0x6f, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69, 0x63, 0x54, 0x6f, 0x4c,
+	0x6f, 0x63, 0x61, 0x6c, 0x12, 0x22, 0x0a, 0x0c, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x45, 0x78, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x49, 0x50, 0x46, 0x61,
+	0x6d, 0x69, 0x6c, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x49, 0x50, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x1e, 0x0a, 0x0a, 0x49, 0x50, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x0f,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x49, 0x50, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x69, 0x65, 0x73,
+	0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x41,
+	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x11, 0x0a, 0x0f, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x22, 0x5f,
+	0x0a, 0x08, 0x49, 0x50, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x09, 0x54, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x49, 0x50, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
 	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x49, 0x50, 0x53, 0x65, 0x74,
-	0x52, 0x0a, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x49, 0x50, 0x73, 0x12, 0x33, 0x0a, 0x0b,
-	0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x50, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x49,
-	0x50, 0x53, 0x65, 0x74, 0x52, 0x0b, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x50,
-	0x73, 0x12, 0x3b, 0x0a, 0x0f, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
-	0x72, 0x49, 0x50, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x63,
-	0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x49, 0x50, 0x53, 0x65, 0x74, 0x52, 0x0f, 0x4c,
-	0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x49, 0x50, 0x73, 0x12, 0x1a,
-	0x0a, 0x08, 0x48, 0x65, 0x61, 0x64, 0x6c, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x08, 0x48, 0x65, 0x61, 0x64, 0x6c, 0x65, 0x73, 0x73, 0x22, 0xf2, 0x01, 0x0a, 0x08, 0x45,
-	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x48, 0x6f, 0x73, 0x74, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x03, 0x49, 0x50, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x49, 0x50,
-	0x53, 0x65, 0x74, 0x52, 0x03, 0x49, 0x50, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x4c, 0x6f, 0x63, 0x61,
-	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x12, 0x4d,
-	0x0a, 0x0d, 0x50, 0x6f, 0x72, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x18,
-	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74,
-	0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x50, 0x6f, 0x72, 0x74,
-	0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d,
-	0x50, 0x6f, 0x72, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x1a, 0x40, 0x0a,
-	0x12, 0x50, 0x6f, 0x72, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
-	0x27, 0x0a, 0x05, 0x49, 0x50, 0x53, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x56, 0x34, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x56, 0x34, 0x12, 0x0e, 0x0a, 0x02, 0x56, 0x36, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x56, 0x36, 0x22, 0x60, 0x0a, 0x04, 0x50, 0x6f, 0x72, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65,
-	0x74, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x08, 0x50, 0x72,
-	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x22, 0xcb, 0x01, 0x0a, 0x0b, 0x50,
-	0x6f, 0x72, 0x74, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x30,
-	0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x50, 0x72,
-	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
-	0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
-	0x50, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x72, 0x74,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x6f, 0x72, 0x74,
-	0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x6f, 0x72, 0x74,
-	0x12, 0x26, 0x0a, 0x0e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x4e, 0x61,
-	0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x50, 0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x3a, 0x0a, 0x10, 0x43, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x49, 0x50, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x12, 0x26, 0x0a, 0x0e,
-	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63,
-	0x6f, 0x6e, 0x64, 0x73, 0x22, 0x50, 0x0a, 0x0b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49,
-	0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2d, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
-	0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x22, 0xfb, 0x02, 0x0a, 0x0c, 0x45, 0x6e, 0x64, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x4e,
-	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x50,
-	0x6f, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x50, 0x6f,
-	0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
-	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e,
-	0x65, 0x74, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x45,
-	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x64, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6c, 0x6f,
-	0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
-	0x74, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0a, 0x43, 0x6f, 0x6e,
-	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x34, 0x0a, 0x08, 0x54, 0x6f, 0x70, 0x6f, 0x6c,
-	0x6f, 0x67, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x6f, 0x63, 0x61,
-	0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x08, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x2f, 0x0a,
-	0x05, 0x48, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c,
-	0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f,
-	0x67, 0x79, 0x48, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x05, 0x48, 0x69, 0x6e, 0x74, 0x73, 0x4a, 0x04,
-	0x08, 0x05, 0x10, 0x06, 0x22, 0x2a, 0x0a, 0x12, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
-	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x52, 0x65,
-	0x61, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x52, 0x65, 0x61, 0x64, 0x79,
-	0x22, 0x36, 0x0a, 0x0c, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x49, 0x6e, 0x66, 0x6f,
-	0x12, 0x12, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x5a, 0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x5a, 0x6f, 0x6e, 0x65, 0x22, 0x25, 0x0a, 0x0d, 0x54, 0x6f, 0x70, 0x6f,
-	0x6c, 0x6f, 0x67, 0x79, 0x48, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x5a, 0x6f, 0x6e,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x5a, 0x6f, 0x6e, 0x65, 0x73, 0x22,
-	0x44, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x48,
-	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x12,
-	0x24, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52,
-	0x04, 0x4e, 0x6f, 0x64, 0x65, 0x22, 0xc6, 0x02, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61,
-	0x6d, 0x65, 0x12, 0x34, 0x0a, 0x08, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76,
-	0x31, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08,
-	0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x34, 0x0a, 0x06, 0x4c, 0x61, 0x62, 0x65,
-	0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
-	0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x43,
-	0x0a, 0x0b, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31,
-	0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e,
-	0x0a, 0x10, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x10,
-	0x0a, 0x0e, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71,
-	0x2a, 0x7e, 0x0a, 0x03, 0x53, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x0a, 0x55, 0x6e, 0x6b, 0x6e, 0x6f,
-	0x77, 0x6e, 0x53, 0x65, 0x74, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x73, 0x53, 0x65, 0x74, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x45, 0x6e, 0x64, 0x70,
-	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x53, 0x65, 0x74, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x47, 0x6c,
-	0x6f, 0x62, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x73,
-	0x10, 0x0a, 0x12, 0x17, 0x0a, 0x13, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x45, 0x6e, 0x64, 0x70,
-	0x6f, 0x69, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x10, 0x0b, 0x12, 0x13, 0x0a, 0x0f, 0x47,
-	0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x10, 0x0c,
-	0x2a, 0x3b, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x13, 0x0a, 0x0f,
-	0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x10,
-	0x00, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x43, 0x50, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x44,
-	0x50, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x43, 0x54, 0x50, 0x10, 0x03, 0x32, 0x42, 0x0a,
-	0x09, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x35, 0x0a, 0x05, 0x57, 0x61,
-	0x74, 0x63, 0x68, 0x12, 0x14, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31,
-	0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x6c, 0x6f, 0x63, 0x61,
-	0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x49, 0x74, 0x65, 0x6d, 0x28, 0x01, 0x30,
-	0x01, 0x32, 0x45, 0x0a, 0x06, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x12, 0x3b, 0x0a, 0x05, 0x57,
-	0x61, 0x74, 0x63, 0x68, 0x12, 0x1a, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76,
-	0x31, 0x2e, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71,
-	0x1a, 0x12, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x4f, 0x70,
-	0x49, 0x74, 0x65, 0x6d, 0x28, 0x01, 0x30, 0x01, 0x42, 0x2c, 0x5a, 0x2a, 0x73, 0x69, 0x67, 0x73,
-	0x2e, 0x6b, 0x38, 0x73, 0x2e, 0x69, 0x6f, 0x2f, 0x6b, 0x70, 0x6e, 0x67, 0x2f, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x6f, 0x63, 0x61,
-	0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x09, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x50, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0c, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22,
+	0xcd, 0x01, 0x0a, 0x0a, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x50, 0x73, 0x12, 0x31,
+	0x0a, 0x0a, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x49, 0x50, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e,
+	0x49, 0x50, 0x53, 0x65, 0x74, 0x52, 0x0a, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x49, 0x50,
+	0x73, 0x12, 0x33, 0x0a, 0x0b, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x50, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65,
+	0x74, 0x76, 0x31, 0x2e, 0x49, 0x50, 0x53, 0x65, 0x74, 0x52, 0x0b, 0x45, 0x78, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x49, 0x50, 0x73, 0x12, 0x3b, 0x0a, 0x0f, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x49, 0x50, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x49, 0x50, 0x53,
+	0x65, 0x74, 0x52, 0x0f, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72,
+	0x49, 0x50, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x48, 0x65, 0x61, 0x64, 0x6c, 0x65, 0x73, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x48, 0x65, 0x61, 0x64, 0x6c, 0x65, 0x73, 0x73, 0x22,
+	0xf0, 0x02, 0x0a, 0x08, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x03, 0x49, 0x50, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74,
+	0x76, 0x31, 0x2e, 0x49, 0x50, 0x53, 0x65, 0x74, 0x52, 0x03, 0x49, 0x50, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x4c, 0x6f,
+	0x63, 0x61, 0x6c, 0x12, 0x4d, 0x0a, 0x0d, 0x50, 0x6f, 0x72, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x72,
+	0x69, 0x64, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6c, 0x6f, 0x63,
+	0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x2e, 0x50, 0x6f, 0x72, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0d, 0x50, 0x6f, 0x72, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
+	0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6e,
+	0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x4e, 0x6f, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x4e, 0x6f, 0x74, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x4e, 0x6f, 0x74, 0x45, 0x78, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x4e, 0x6f, 0x74,
+	0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x57, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x1a, 0x40, 0x0a, 0x12, 0x50, 0x6f, 0x72, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x27, 0x0a, 0x05, 0x49, 0x50, 0x53, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x56,
+	0x34, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x56, 0x34, 0x12, 0x0e, 0x0a, 0x02, 0x56,
+	0x36, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x56, 0x36, 0x22, 0x60, 0x0a, 0x04, 0x50,
+	0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52,
+	0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f, 0x72,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x22, 0xed, 0x01,
+	0x0a, 0x0b, 0x50, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a,
+	0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x30, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31,
+	0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x50,
+	0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x50,
+	0x6f, 0x72, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x6f, 0x72,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50,
+	0x6f, 0x72, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x6f, 0x72,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x41,
+	0x70, 0x70, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x41, 0x70, 0x70, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x22, 0x3a, 0x0a,
+	0x10, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x50, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74,
+	0x79, 0x12, 0x26, 0x0a, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x50, 0x0a, 0x0b, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61, 0x73, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2d, 0x0a, 0x07,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x52, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x22, 0xfb, 0x02, 0x0a, 0x0c,
+	0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04,
+	0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x1c, 0x0a, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20,
+	0x0a, 0x0b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x50, 0x6f, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x50, 0x6f, 0x64, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x45, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x52, 0x08, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x0a,
+	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x45, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x0a, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x34, 0x0a, 0x08,
+	0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x6f,
+	0x6c, 0x6f, 0x67, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f,
+	0x67, 0x79, 0x12, 0x2f, 0x0a, 0x05, 0x48, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x54,
+	0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x48, 0x69, 0x6e, 0x74, 0x73, 0x52, 0x05, 0x48, 0x69,
+	0x6e, 0x74, 0x73, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x22, 0x66, 0x0a, 0x12, 0x45, 0x6e, 0x64,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x52, 0x65, 0x61, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x52, 0x65, 0x61, 0x64, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x12,
+	0x20, 0x0a, 0x0b, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6e,
+	0x67, 0x22, 0x36, 0x0a, 0x0c, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x5a, 0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x5a, 0x6f, 0x6e, 0x65, 0x22, 0x25, 0x0a, 0x0d, 0x54, 0x6f, 0x70,
+	0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x48, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x5a, 0x6f,
+	0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x5a, 0x6f, 0x6e, 0x65, 0x73,
+	0x22, 0x44, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04,
+	0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x24, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x22, 0x99, 0x03, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x34, 0x0a, 0x08, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74,
+	0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x08, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x34, 0x0a, 0x06, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12,
+	0x43, 0x0a, 0x0b, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76,
+	0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x35, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e,
+	0x65, 0x74, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x52, 0x09, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x50,
+	0x6f, 0x64, 0x43, 0x49, 0x44, 0x52, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x50,
+	0x6f, 0x64, 0x43, 0x49, 0x44, 0x52, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x3b, 0x0a, 0x0b, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22,
+	0xa0, 0x01, 0x0a, 0x0e, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x71, 0x12, 0x38, 0x0a, 0x0c, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0c,
+	0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x06,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x23, 0x0a,
+	0x04, 0x53, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x04, 0x53, 0x65,
+	0x74, 0x73, 0x22, 0x10, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x22, 0x73, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08,
+	0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x07, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x07, 0x57, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x22, 0xe0, 0x02, 0x0a, 0x09, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x02, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x41, 0x50, 0x49, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x41, 0x50, 0x49, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x65, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x65, 0x65, 0x72, 0x12, 0x1a, 0x0a,
+	0x08, 0x4e, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x4e, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x38, 0x0a, 0x0c, 0x4c, 0x61, 0x73, 0x74, 0x52,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x4c, 0x61, 0x73, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e, 0x63, 0x54, 0x69, 0x6d,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x4c, 0x61, 0x73, 0x74, 0x53, 0x79, 0x6e,
+	0x63, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x42, 0x65, 0x68, 0x69, 0x6e, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f,
+	0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x65, 0x68, 0x69, 0x6e, 0x64, 0x12,
+	0x24, 0x0a, 0x0d, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x42, 0x65, 0x68, 0x69, 0x6e, 0x64,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x42,
+	0x65, 0x68, 0x69, 0x6e, 0x64, 0x12, 0x35, 0x0a, 0x09, 0x4c, 0x61, 0x73, 0x74, 0x41, 0x70, 0x70,
+	0x6c, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x09, 0x4c, 0x61, 0x73, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x22, 0x1f, 0x0a, 0x0d,
+	0x52, 0x65, 0x73, 0x65, 0x74, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a,
+	0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x49, 0x44, 0x22, 0x0f, 0x0a,
+	0x0d, 0x52, 0x65, 0x73, 0x65, 0x74, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x22, 0x16,
+	0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x22, 0x49, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x70,
+	0x70, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x12, 0x31,
+	0x0a, 0x05, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x41,
+	0x70, 0x70, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x4e, 0x6f, 0x64, 0x65,
+	0x73, 0x22, 0x8e, 0x01, 0x0a, 0x0f, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x2f, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x41,
+	0x70, 0x70, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x73, 0x2a, 0x8c, 0x01, 0x0a, 0x03, 0x53, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x0a, 0x55, 0x6e,
+	0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x53, 0x65, 0x74, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x53, 0x65, 0x74, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x45,
+	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x53, 0x65, 0x74, 0x10, 0x02, 0x12, 0x0c, 0x0a,
+	0x08, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x53, 0x65, 0x74, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x47,
+	0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x73, 0x10, 0x0a, 0x12, 0x17, 0x0a, 0x13, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x45, 0x6e, 0x64,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x10, 0x0b, 0x12, 0x13, 0x0a, 0x0f,
+	0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x10,
+	0x0c, 0x2a, 0x3b, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x13, 0x0a,
+	0x0f, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x43, 0x50, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x55,
+	0x44, 0x50, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x43, 0x54, 0x50, 0x10, 0x03, 0x32, 0x42,
+	0x0a, 0x09, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x35, 0x0a, 0x05, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x14, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76,
+	0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x6c, 0x6f, 0x63,
+	0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x49, 0x74, 0x65, 0x6d, 0x28, 0x01,
+	0x30, 0x01, 0x32, 0x45, 0x0a, 0x06, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x12, 0x3b, 0x0a, 0x05,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1a, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74,
+	0x76, 0x31, 0x2e, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x71, 0x1a, 0x12, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x4f,
+	0x70, 0x49, 0x74, 0x65, 0x6d, 0x28, 0x01, 0x30, 0x01, 0x32, 0xeb, 0x01, 0x0a, 0x05, 0x41, 0x64,
+	0x6d, 0x69, 0x6e, 0x12, 0x45, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x12, 0x1a, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x57, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1a,
+	0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x0a, 0x52, 0x65,
+	0x73, 0x65, 0x74, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x19, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x6e, 0x65, 0x74, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x12, 0x57,
+	0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x20, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x6e, 0x65, 0x74,
+	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x42, 0x2c, 0x5a, 0x2a, 0x73, 0x69, 0x67, 0x73, 0x2e,
+	0x6b, 0x38, 0x73, 0x2e, 0x69, 0x6f, 0x2f, 0x6b, 0x70, 0x6e, 0x67, 0x2f, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x6e, 0x65, 0x74, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1772,76 +2941,114 @@ func file_api_localnetv1_services_proto_rawDescGZIP() []byte {
 }
 
 var file_api_localnetv1_services_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_localnetv1_services_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_api_localnetv1_services_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_api_localnetv1_services_proto_goTypes = []interface{}{
-	(Set)(0),                   // 0: localnetv1.Set
-	(Protocol)(0),              // 1: localnetv1.Protocol
-	(*WatchReq)(nil),           // 2: localnetv1.WatchReq
-	(*OpItem)(nil),             // 3: localnetv1.OpItem
-	(*EmptyOp)(nil),            // 4: localnetv1.EmptyOp
-	(*Ref)(nil),                // 5: localnetv1.Ref
-	(*Value)(nil),              // 6: localnetv1.Value
-	(*Service)(nil),            // 7: localnetv1.Service
-	(*IPFilter)(nil),           // 8: localnetv1.IPFilter
-	(*ServiceIPs)(nil),         // 9: localnetv1.ServiceIPs
-	(*Endpoint)(nil),           // 10: localnetv1.Endpoint
-	(*IPSet)(nil),              // 11: localnetv1.IPSet
-	(*Port)(nil),               // 12: localnetv1.Port
-	(*PortMapping)(nil),        // 13: localnetv1.PortMapping
-	(*ClientIPAffinity)(nil),   // 14: localnetv1.ClientIPAffinity
-	(*ServiceInfo)(nil),        // 15: localnetv1.ServiceInfo
-	(*EndpointInfo)(nil),       // 16: localnetv1.EndpointInfo
-	(*EndpointConditions)(nil), // 17: localnetv1.EndpointConditions
-	(*TopologyInfo)(nil),       // 18: localnetv1.TopologyInfo
-	(*TopologyHints)(nil),      // 19: localnetv1.TopologyHints
-	(*NodeInfo)(nil),           // 20: localnetv1.NodeInfo
-	(*Node)(nil),               // 21: localnetv1.Node
-	(*GlobalWatchReq)(nil),     // 22: localnetv1.GlobalWatchReq
-	nil,                        // 23: localnetv1.Service.LabelsEntry
-	nil,                        // 24: localnetv1.Service.AnnotationsEntry
-	nil,                        // 25: localnetv1.Endpoint.PortOverridesEntry
-	nil,                        // 26: localnetv1.Node.LabelsEntry
-	nil,                        // 27: localnetv1.Node.AnnotationsEntry
+	(Set)(0),                     // 0: localnetv1.Set
+	(Protocol)(0),                // 1: localnetv1.Protocol
+	(*WatchReq)(nil),             // 2: localnetv1.WatchReq
+	(*ApplyStatus)(nil),          // 3: localnetv1.ApplyStatus
+	(*WatchFilter)(nil),          // 4: localnetv1.WatchFilter
+	(*OpItem)(nil),               // 5: localnetv1.OpItem
+	(*Revision)(nil),             // 6: localnetv1.Revision
+	(*EmptyOp)(nil),              // 7: localnetv1.EmptyOp
+	(*RecordedOp)(nil),           // 8: localnetv1.RecordedOp
+	(*StoreSnapshot)(nil),        // 9: localnetv1.StoreSnapshot
+	(*Ref)(nil),                  // 10: localnetv1.Ref
+	(*Value)(nil),                // 11: localnetv1.Value
+	(*Service)(nil),              // 12: localnetv1.Service
+	(*IPFilter)(nil),             // 13: localnetv1.IPFilter
+	(*ServiceIPs)(nil),           // 14: localnetv1.ServiceIPs
+	(*Endpoint)(nil),             // 15: localnetv1.Endpoint
+	(*IPSet)(nil),                // 16: localnetv1.IPSet
+	(*Port)(nil),                 // 17: localnetv1.Port
+	(*PortMapping)(nil),          // 18: localnetv1.PortMapping
+	(*ClientIPAffinity)(nil),     // 19: localnetv1.ClientIPAffinity
+	(*ServiceInfo)(nil),          // 20: localnetv1.ServiceInfo
+	(*EndpointInfo)(nil),         // 21: localnetv1.EndpointInfo
+	(*EndpointConditions)(nil),   // 22: localnetv1.EndpointConditions
+	(*TopologyInfo)(nil),         // 23: localnetv1.TopologyInfo
+	(*TopologyHints)(nil),        // 24: localnetv1.TopologyHints
+	(*NodeInfo)(nil),             // 25: localnetv1.NodeInfo
+	(*Node)(nil),                 // 26: localnetv1.Node
+	(*NodeAddress)(nil),          // 27: localnetv1.NodeAddress
+	(*GlobalWatchReq)(nil),       // 28: localnetv1.GlobalWatchReq
+	(*ListWatchesReq)(nil),       // 29: localnetv1.ListWatchesReq
+	(*ListWatchesRes)(nil),       // 30: localnetv1.ListWatchesRes
+	(*WatchInfo)(nil),            // 31: localnetv1.WatchInfo
+	(*ResetWatchReq)(nil),        // 32: localnetv1.ResetWatchReq
+	(*ResetWatchRes)(nil),        // 33: localnetv1.ResetWatchRes
+	(*ListApplyStatusesReq)(nil), // 34: localnetv1.ListApplyStatusesReq
+	(*ListApplyStatusesRes)(nil), // 35: localnetv1.ListApplyStatusesRes
+	(*NodeApplyStatus)(nil),      // 36: localnetv1.NodeApplyStatus
+	nil,                          // 37: localnetv1.Service.LabelsEntry
+	nil,                          // 38: localnetv1.Service.AnnotationsEntry
+	nil,                          // 39: localnetv1.Endpoint.PortOverridesEntry
+	nil,                          // 40: localnetv1.Node.LabelsEntry
+	nil,                          // 41: localnetv1.Node.AnnotationsEntry
 }
 var file_api_localnetv1_services_proto_depIdxs = []int32{
-	4,  // 0: localnetv1.OpItem.Sync:type_name -> localnetv1.EmptyOp
-	4,  // 1: localnetv1.OpItem.Reset:type_name -> localnetv1.EmptyOp
-	6,  // 2: localnetv1.OpItem.Set:type_name -> localnetv1.Value
-	5,  // 3: localnetv1.OpItem.Delete:type_name -> localnetv1.Ref
-	0,  // 4: localnetv1.Ref.Set:type_name -> localnetv1.Set
-	5,  // 5: localnetv1.Value.Ref:type_name -> localnetv1.Ref
-	23, // 6: localnetv1.Service.Labels:type_name -> localnetv1.Service.LabelsEntry
-	24, // 7: localnetv1.Service.Annotations:type_name -> localnetv1.Service.AnnotationsEntry
-	9,  // 8: localnetv1.Service.IPs:type_name -> localnetv1.ServiceIPs
-	8,  // 9: localnetv1.Service.IPFilters:type_name -> localnetv1.IPFilter
-	13, // 10: localnetv1.Service.Ports:type_name -> localnetv1.PortMapping
-	14, // 11: localnetv1.Service.ClientIP:type_name -> localnetv1.ClientIPAffinity
-	11, // 12: localnetv1.IPFilter.TargetIPs:type_name -> localnetv1.IPSet
-	11, // 13: localnetv1.ServiceIPs.ClusterIPs:type_name -> localnetv1.IPSet
-	11, // 14: localnetv1.ServiceIPs.ExternalIPs:type_name -> localnetv1.IPSet
-	11, // 15: localnetv1.ServiceIPs.LoadBalancerIPs:type_name -> localnetv1.IPSet
-	11, // 16: localnetv1.Endpoint.IPs:type_name -> localnetv1.IPSet
-	25, // 17: localnetv1.Endpoint.PortOverrides:type_name -> localnetv1.Endpoint.PortOverridesEntry
-	1,  // 18: localnetv1.Port.Protocol:type_name -> localnetv1.Protocol
-	1,  // 19: localnetv1.PortMapping.Protocol:type_name -> localnetv1.Protocol
-	7,  // 20: localnetv1.ServiceInfo.Service:type_name -> localnetv1.Service
-	10, // 21: localnetv1.EndpointInfo.Endpoint:type_name -> localnetv1.Endpoint
-	17, // 22: localnetv1.EndpointInfo.Conditions:type_name -> localnetv1.EndpointConditions
-	18, // 23: localnetv1.EndpointInfo.Topology:type_name -> localnetv1.TopologyInfo
-	19, // 24: localnetv1.EndpointInfo.Hints:type_name -> localnetv1.TopologyHints
-	21, // 25: localnetv1.NodeInfo.Node:type_name -> localnetv1.Node
-	18, // 26: localnetv1.Node.Topology:type_name -> localnetv1.TopologyInfo
-	26, // 27: localnetv1.Node.Labels:type_name -> localnetv1.Node.LabelsEntry
-	27, // 28: localnetv1.Node.Annotations:type_name -> localnetv1.Node.AnnotationsEntry
-	2,  // 29: localnetv1.Endpoints.Watch:input_type -> localnetv1.WatchReq
-	22, // 30: localnetv1.Global.Watch:input_type -> localnetv1.GlobalWatchReq
-	3,  // 31: localnetv1.Endpoints.Watch:output_type -> localnetv1.OpItem
-	3,  // 32: localnetv1.Global.Watch:output_type -> localnetv1.OpItem
-	31, // [31:33] is the sub-list for method output_type
-	29, // [29:31] is the sub-list for method input_type
-	29, // [29:29] is the sub-list for extension type_name
-	29, // [29:29] is the sub-list for extension extendee
-	0,  // [0:29] is the sub-list for field type_name
+	6,  // 0: localnetv1.WatchReq.LastRevision:type_name -> localnetv1.Revision
+	4,  // 1: localnetv1.WatchReq.Filter:type_name -> localnetv1.WatchFilter
+	3,  // 2: localnetv1.WatchReq.ApplyStatus:type_name -> localnetv1.ApplyStatus
+	6,  // 3: localnetv1.ApplyStatus.Revision:type_name -> localnetv1.Revision
+	7,  // 4: localnetv1.OpItem.Sync:type_name -> localnetv1.EmptyOp
+	7,  // 5: localnetv1.OpItem.Reset:type_name -> localnetv1.EmptyOp
+	11, // 6: localnetv1.OpItem.Set:type_name -> localnetv1.Value
+	10, // 7: localnetv1.OpItem.Delete:type_name -> localnetv1.Ref
+	6,  // 8: localnetv1.OpItem.Revision:type_name -> localnetv1.Revision
+	5,  // 9: localnetv1.RecordedOp.Op:type_name -> localnetv1.OpItem
+	0,  // 10: localnetv1.StoreSnapshot.SyncedSets:type_name -> localnetv1.Set
+	11, // 11: localnetv1.StoreSnapshot.Values:type_name -> localnetv1.Value
+	0,  // 12: localnetv1.Ref.Set:type_name -> localnetv1.Set
+	10, // 13: localnetv1.Value.Ref:type_name -> localnetv1.Ref
+	37, // 14: localnetv1.Service.Labels:type_name -> localnetv1.Service.LabelsEntry
+	38, // 15: localnetv1.Service.Annotations:type_name -> localnetv1.Service.AnnotationsEntry
+	14, // 16: localnetv1.Service.IPs:type_name -> localnetv1.ServiceIPs
+	13, // 17: localnetv1.Service.IPFilters:type_name -> localnetv1.IPFilter
+	18, // 18: localnetv1.Service.Ports:type_name -> localnetv1.PortMapping
+	19, // 19: localnetv1.Service.ClientIP:type_name -> localnetv1.ClientIPAffinity
+	16, // 20: localnetv1.IPFilter.TargetIPs:type_name -> localnetv1.IPSet
+	16, // 21: localnetv1.ServiceIPs.ClusterIPs:type_name -> localnetv1.IPSet
+	16, // 22: localnetv1.ServiceIPs.ExternalIPs:type_name -> localnetv1.IPSet
+	16, // 23: localnetv1.ServiceIPs.LoadBalancerIPs:type_name -> localnetv1.IPSet
+	16, // 24: localnetv1.Endpoint.IPs:type_name -> localnetv1.IPSet
+	39, // 25: localnetv1.Endpoint.PortOverrides:type_name -> localnetv1.Endpoint.PortOverridesEntry
+	1,  // 26: localnetv1.Port.Protocol:type_name -> localnetv1.Protocol
+	1,  // 27: localnetv1.PortMapping.Protocol:type_name -> localnetv1.Protocol
+	12, // 28: localnetv1.ServiceInfo.Service:type_name -> localnetv1.Service
+	15, // 29: localnetv1.EndpointInfo.Endpoint:type_name -> localnetv1.Endpoint
+	22, // 30: localnetv1.EndpointInfo.Conditions:type_name -> localnetv1.EndpointConditions
+	23, // 31: localnetv1.EndpointInfo.Topology:type_name -> localnetv1.TopologyInfo
+	24, // 32: localnetv1.EndpointInfo.Hints:type_name -> localnetv1.TopologyHints
+	26, // 33: localnetv1.NodeInfo.Node:type_name -> localnetv1.Node
+	23, // 34: localnetv1.Node.Topology:type_name -> localnetv1.TopologyInfo
+	40, // 35: localnetv1.Node.Labels:type_name -> localnetv1.Node.LabelsEntry
+	41, // 36: localnetv1.Node.Annotations:type_name -> localnetv1.Node.AnnotationsEntry
+	27, // 37: localnetv1.Node.Addresses:type_name -> localnetv1.NodeAddress
+	6,  // 38: localnetv1.GlobalWatchReq.LastRevision:type_name -> localnetv1.Revision
+	4,  // 39: localnetv1.GlobalWatchReq.Filter:type_name -> localnetv1.WatchFilter
+	0,  // 40: localnetv1.GlobalWatchReq.Sets:type_name -> localnetv1.Set
+	6,  // 41: localnetv1.ListWatchesRes.Revision:type_name -> localnetv1.Revision
+	31, // 42: localnetv1.ListWatchesRes.Watches:type_name -> localnetv1.WatchInfo
+	6,  // 43: localnetv1.WatchInfo.LastRevision:type_name -> localnetv1.Revision
+	3,  // 44: localnetv1.WatchInfo.LastApply:type_name -> localnetv1.ApplyStatus
+	36, // 45: localnetv1.ListApplyStatusesRes.Nodes:type_name -> localnetv1.NodeApplyStatus
+	3,  // 46: localnetv1.NodeApplyStatus.Status:type_name -> localnetv1.ApplyStatus
+	2,  // 47: localnetv1.Endpoints.Watch:input_type -> localnetv1.WatchReq
+	28, // 48: localnetv1.Global.Watch:input_type -> localnetv1.GlobalWatchReq
+	29, // 49: localnetv1.Admin.ListWatches:input_type -> localnetv1.ListWatchesReq
+	32, // 50: localnetv1.Admin.ResetWatch:input_type -> localnetv1.ResetWatchReq
+	34, // 51: localnetv1.Admin.ListApplyStatuses:input_type -> localnetv1.ListApplyStatusesReq
+	5,  // 52: localnetv1.Endpoints.Watch:output_type -> localnetv1.OpItem
+	5,  // 53: localnetv1.Global.Watch:output_type -> localnetv1.OpItem
+	30, // 54: localnetv1.Admin.ListWatches:output_type -> localnetv1.ListWatchesRes
+	33, // 55: localnetv1.Admin.ResetWatch:output_type -> localnetv1.ResetWatchRes
+	35, // 56: localnetv1.Admin.ListApplyStatuses:output_type -> localnetv1.ListApplyStatusesRes
+	52, // [52:57] is the sub-list for method output_type
+	47, // [47:52] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_api_localnetv1_services_proto_init() }
@@ -1863,7 +3070,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OpItem); i {
+			switch v := v.(*ApplyStatus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1875,7 +3082,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EmptyOp); i {
+			switch v := v.(*WatchFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1887,7 +3094,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Ref); i {
+			switch v := v.(*OpItem); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1899,7 +3106,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Value); i {
+			switch v := v.(*Revision); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1911,7 +3118,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Service); i {
+			switch v := v.(*EmptyOp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1923,7 +3130,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IPFilter); i {
+			switch v := v.(*RecordedOp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1935,7 +3142,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServiceIPs); i {
+			switch v := v.(*StoreSnapshot); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1947,7 +3154,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Endpoint); i {
+			switch v := v.(*Ref); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1959,7 +3166,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IPSet); i {
+			switch v := v.(*Value); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1971,7 +3178,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Port); i {
+			switch v := v.(*Service); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1983,7 +3190,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PortMapping); i {
+			switch v := v.(*IPFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1995,7 +3202,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientIPAffinity); i {
+			switch v := v.(*ServiceIPs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2007,7 +3214,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServiceInfo); i {
+			switch v := v.(*Endpoint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2019,7 +3226,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EndpointInfo); i {
+			switch v := v.(*IPSet); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2031,7 +3238,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EndpointConditions); i {
+			switch v := v.(*Port); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2043,7 +3250,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopologyInfo); i {
+			switch v := v.(*PortMapping); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2055,7 +3262,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopologyHints); i {
+			switch v := v.(*ClientIPAffinity); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2067,7 +3274,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NodeInfo); i {
+			switch v := v.(*ServiceInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2079,7 +3286,7 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Node); i {
+			switch v := v.(*EndpointInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2091,6 +3298,78 @@ func file_api_localnetv1_services_proto_init() {
 			}
 		}
 		file_api_localnetv1_services_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EndpointConditions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_localnetv1_services_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopologyInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_localnetv1_services_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopologyHints); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_localnetv1_services_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_localnetv1_services_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Node); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_localnetv1_services_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeAddress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_localnetv1_services_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GlobalWatchReq); i {
 			case 0:
 				return &v.state
@@ -2102,14 +3381,110 @@ func file_api_localnetv1_services_proto_init() {
 				return nil
 			}
 		}
+		file_api_localnetv1_services_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListWatchesReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_localnetv1_services_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListWatchesRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_localnetv1_services_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_localnetv1_services_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResetWatchReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_localnetv1_services_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResetWatchRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_localnetv1_services_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListApplyStatusesReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_localnetv1_services_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListApplyStatusesRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_localnetv1_services_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeApplyStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_api_localnetv1_services_proto_msgTypes[1].OneofWrappers = []interface{}{
+	file_api_localnetv1_services_proto_msgTypes[3].OneofWrappers = []interface{}{
 		(*OpItem_Sync)(nil),
 		(*OpItem_Reset_)(nil),
 		(*OpItem_Set)(nil),
 		(*OpItem_Delete)(nil),
 	}
-	file_api_localnetv1_services_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_api_localnetv1_services_proto_msgTypes[10].OneofWrappers = []interface{}{
 		(*Service_ClientIP)(nil),
 	}
 	type x struct{}
@@ -2118,9 +3493,9 @@ func file_api_localnetv1_services_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_localnetv1_services_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   26,
+			NumMessages:   40,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   3,
 		},
 		GoTypes:           file_api_localnetv1_services_proto_goTypes,
 		DependencyIndexes: file_api_localnetv1_services_proto_depIdxs,
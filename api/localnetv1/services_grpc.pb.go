@@ -251,3 +251,167 @@ var Global_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "api/localnetv1/services.proto",
 }
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminClient interface {
+	// Lists the active watches of the local and global APIs.
+	ListWatches(ctx context.Context, in *ListWatchesReq, opts ...grpc.CallOption) (*ListWatchesRes, error)
+	// Forces a watch to receive the whole data set again, with its next change set.
+	ResetWatch(ctx context.Context, in *ResetWatchReq, opts ...grpc.CallOption) (*ResetWatchRes, error)
+	// Lists the last apply status reported by each node.
+	ListApplyStatuses(ctx context.Context, in *ListApplyStatusesReq, opts ...grpc.CallOption) (*ListApplyStatusesRes, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) ListWatches(ctx context.Context, in *ListWatchesReq, opts ...grpc.CallOption) (*ListWatchesRes, error) {
+	out := new(ListWatchesRes)
+	err := c.cc.Invoke(ctx, "/localnetv1.Admin/ListWatches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ResetWatch(ctx context.Context, in *ResetWatchReq, opts ...grpc.CallOption) (*ResetWatchRes, error) {
+	out := new(ResetWatchRes)
+	err := c.cc.Invoke(ctx, "/localnetv1.Admin/ResetWatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListApplyStatuses(ctx context.Context, in *ListApplyStatusesReq, opts ...grpc.CallOption) (*ListApplyStatusesRes, error) {
+	out := new(ListApplyStatusesRes)
+	err := c.cc.Invoke(ctx, "/localnetv1.Admin/ListApplyStatuses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility
+type AdminServer interface {
+	// Lists the active watches of the local and global APIs.
+	ListWatches(context.Context, *ListWatchesReq) (*ListWatchesRes, error)
+	// Forces a watch to receive the whole data set again, with its next change set.
+	ResetWatch(context.Context, *ResetWatchReq) (*ResetWatchRes, error)
+	// Lists the last apply status reported by each node.
+	ListApplyStatuses(context.Context, *ListApplyStatusesReq) (*ListApplyStatusesRes, error)
+	mustEmbedUnimplementedAdminServer()
+}
+
+// UnimplementedAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServer struct {
+}
+
+func (UnimplementedAdminServer) ListWatches(context.Context, *ListWatchesReq) (*ListWatchesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWatches not implemented")
+}
+func (UnimplementedAdminServer) ResetWatch(context.Context, *ResetWatchReq) (*ResetWatchRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetWatch not implemented")
+}
+func (UnimplementedAdminServer) ListApplyStatuses(context.Context, *ListApplyStatusesReq) (*ListApplyStatusesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApplyStatuses not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
+// result in compilation errors.
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
+}
+
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	s.RegisterService(&Admin_ServiceDesc, srv)
+}
+
+func _Admin_ListWatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWatchesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListWatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localnetv1.Admin/ListWatches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListWatches(ctx, req.(*ListWatchesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ResetWatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetWatchReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ResetWatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localnetv1.Admin/ResetWatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ResetWatch(ctx, req.(*ResetWatchReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListApplyStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApplyStatusesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListApplyStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/localnetv1.Admin/ListApplyStatuses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListApplyStatuses(ctx, req.(*ListApplyStatusesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "localnetv1.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListWatches",
+			Handler:    _Admin_ListWatches_Handler,
+		},
+		{
+			MethodName: "ResetWatch",
+			Handler:    _Admin_ResetWatch_Handler,
+		},
+		{
+			MethodName: "ListApplyStatuses",
+			Handler:    _Admin_ListApplyStatuses_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/localnetv1/services.proto",
+}
@@ -133,6 +133,10 @@ func (ebc *ebpfController) Callback(ch <-chan *client.ServiceEndpoints) {
 			continue
 		}
 
+		if serviceEndpoints.Service.IPs.Headless {
+			continue
+		}
+
 		svcUniqueName := types.NamespacedName{Name: serviceEndpoints.Service.Name, Namespace: serviceEndpoints.Service.Namespace}
 
 		for i := range serviceEndpoints.Service.Ports {
@@ -140,7 +144,7 @@ func (ebc *ebpfController) Callback(ch <-chan *client.ServiceEndpoints) {
 			svcKey := fmt.Sprintf("%s/%d/%s", svcUniqueName, servicePort.Port, servicePort.Protocol)
 			baseSvcInfo := ebc.newBaseServiceInfo(servicePort, serviceEndpoints.Service)
 
-			svcEndptRelation := svcEndpointMapping{Svc: baseSvcInfo, Endpoint: serviceEndpoints.Endpoints}
+			svcEndptRelation := svcEndpointMapping{Svc: baseSvcInfo, Endpoint: serviceEndpoints.InternalEndpoints()}
 			// JSON encoding of our services + EP information
 			svcEndptRelationBytes := new(bytes.Buffer)
 			json.NewEncoder(svcEndptRelationBytes).Encode(svcEndptRelation)
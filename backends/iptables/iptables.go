@@ -50,6 +50,9 @@ func BindFlags(flags *pflag.FlagSet) {
 }
 
 type iptables struct {
+	// lastSyncError is the error of the last sync (nil if it succeeded)
+	lastSyncError error
+
 	mu         sync.Mutex        // protects the following fields
 	nodeLabels map[string]string //TODO: looks like can be removed as kpng controller shoujld do the work
 
@@ -127,6 +130,8 @@ func NewIptables() *iptables {
 
 func (t *iptables) sync() {
 	defer wg.Done()
+
+	t.lastSyncError = nil
 	// This is where the actual kube-proxy legacy logic takes over...
 
 	// We assume that if this was called, we really want to sync them,
@@ -231,7 +236,7 @@ func (t *iptables) sync() {
 
 			// The logic below this applies only if this service is marked as OnlyLocal
 			if svcInfo.NodeLocalExternal() {
-				t.writeLocalExtTrafficPolicyRules(svcInfo, svcName, localEndpointChains, args[:0])
+				t.writeLocalExtTrafficPolicyRules(svcInfo, svcName, endpoints, endpointChains, localEndpointChains, args[:0])
 			}
 		}
 	}
@@ -246,6 +251,7 @@ func (t *iptables) sync() {
 	if err != nil {
 		klog.ErrorS(err, "Failed to execute iptables-restore")
 		IptablesRestoreFailuresTotal.Inc()
+		t.lastSyncError = fmt.Errorf("iptables-restore failed: %w", err)
 		// Revert new local ports.
 		klog.V(2).InfoS("Closing local ports after iptables-restore failure")
 		RevertPorts(replacementPortsMap, t.portsMap)
@@ -695,6 +701,14 @@ func (t *iptables) writeEndpointLBRules(svcInfo *serviceInfo, svcName types.Name
 	// Now write loadbalancing & DNAT rules.
 	numReadyEndpoints := len(*readyEndpointChains)
 	svcChain := svcInfo.servicePortChainName
+
+	// weights of the endpoints, and the remaining weight from each of them
+	weights, weighted := t.endpointWeights(svcName, readyEndpoints)
+	remainingWeights := make([]int, numReadyEndpoints+1)
+	for i := numReadyEndpoints - 1; i >= 0; i-- {
+		remainingWeights[i] = remainingWeights[i+1] + weights[i]
+	}
+
 	for i, endpointChain := range *readyEndpointChains {
 
 		epIP := readyEndpoints[i]
@@ -711,7 +725,7 @@ func (t *iptables) writeEndpointLBRules(svcInfo *serviceInfo, svcName types.Name
 			args = append(args,
 				"-m", "statistic",
 				"--mode", "random",
-				"--probability", t.probability(numReadyEndpoints-i))
+				"--probability", t.weightedProbability(weighted, numReadyEndpoints-i, weights[i], remainingWeights[i]))
 		}
 		// The final (or only if n == 1) rule is a guaranteed match.
 		args = append(args, "-j", string(endpointChain))
@@ -762,7 +776,8 @@ func (t *iptables) getTargetPort(svcInfo *serviceInfo, endpointPortMap map[strin
 	return svcInfo.TargetPort()
 }
 
-func (t *iptables) writeLocalExtTrafficPolicyRules(svcInfo *serviceInfo, svcName types.NamespacedName, localReadyEndpointChains *[]util.Chain, args []string) {
+func (t *iptables) writeLocalExtTrafficPolicyRules(svcInfo *serviceInfo, svcName types.NamespacedName,
+	endpoints []*string, endpointChains *[]util.Chain, localReadyEndpointChains *[]util.Chain, args []string) {
 	// First rule in the chain redirects all pod -> external VIP traffic to the
 	// Service's ClusterIP instead. This happens whether or not we have local
 	// endpoints; only if localDetector is implemented
@@ -822,6 +837,24 @@ func (t *iptables) writeLocalExtTrafficPolicyRules(svcInfo *serviceInfo, svcName
 			}
 		}
 
+		// weights of the local endpoints, and the remaining weight from each of them
+		isLocal := make(map[util.Chain]bool, numLocalEndpoints)
+		for _, endpointChain := range *localEndpointChains {
+			isLocal[endpointChain] = true
+		}
+		localEndpoints := make([]*string, 0, numLocalEndpoints)
+		for i, endpointChain := range *endpointChains {
+			if isLocal[endpointChain] {
+				localEndpoints = append(localEndpoints, endpoints[i])
+			}
+		}
+
+		weights, weighted := t.endpointWeights(svcName, localEndpoints)
+		remainingWeights := make([]int, numLocalEndpoints+1)
+		for i := numLocalEndpoints - 1; i >= 0; i-- {
+			remainingWeights[i] = remainingWeights[i+1] + weights[i]
+		}
+
 		// Setup probability filter rules only over local endpoints
 		for i, endpointChain := range *localEndpointChains {
 			// Balancing rules in the per-service chain.
@@ -835,7 +868,7 @@ func (t *iptables) writeLocalExtTrafficPolicyRules(svcInfo *serviceInfo, svcName
 				args = append(args,
 					"-m", "statistic",
 					"--mode", "random",
-					"--probability", t.probability(numLocalEndpoints-i))
+					"--probability", t.weightedProbability(weighted, numLocalEndpoints-i, weights[i], remainingWeights[i]))
 			}
 			// The final (or only if n == 1) rule is a guaranteed match.
 			args = append(args, "-j", string(endpointChain))
@@ -1064,3 +1097,37 @@ func (t *iptables) precomputeProbabilities(numberOfPrecomputed int) {
 func (t *iptables) computeProbability(n int) string {
 	return fmt.Sprintf("%0.10f", 1.0/float64(n))
 }
+
+// endpointWeights returns the weights of the given endpoints of a service, and whether they're
+// not all the same (ie: weighted probabilities are needed).
+func (t *iptables) endpointWeights(svcName types.NamespacedName, endpoints []*string) (weights []int, weighted bool) {
+	byIP := map[string]int{}
+	if allEndpoints, ok := t.endpointsMap[svcName]; ok {
+		for _, ep := range *allEndpoints {
+			for _, ip := range append(ep.IPs.V4, ep.IPs.V6...) {
+				byIP[ip] = int(ep.EffectiveWeight())
+			}
+		}
+	}
+
+	weights = make([]int, len(endpoints))
+	for i, ep := range endpoints {
+		weights[i] = byIP[*ep]
+		if weights[i] == 0 {
+			weights[i] = 1
+		}
+		if weights[i] != weights[0] {
+			weighted = true
+		}
+	}
+	return
+}
+
+// weightedProbability returns the probability to select an endpoint of the given weight among the
+// remaining ones (n endpoints with a total weight of remainingWeight).
+func (t *iptables) weightedProbability(weighted bool, n, weight, remainingWeight int) string {
+	if !weighted {
+		return t.probability(n)
+	}
+	return fmt.Sprintf("%0.10f", float64(weight)/float64(remainingWeight))
+}
@@ -34,6 +34,7 @@ import (
 
 type Backend struct {
 	localsink.Config
+	localsink.Status
 }
 
 var wg = sync.WaitGroup{}
@@ -46,7 +47,7 @@ func New() *Backend {
 }
 
 func (s *Backend) Sink() localsink.Sink {
-	return filterreset.New(pipe.New(decoder.New(s), decoder.New(conntrack.NewSink())))
+	return filterreset.New(pipe.New(decoder.New(decoder.InternalOnly(s)), decoder.New(conntrack.NewSink())))
 }
 
 func (s *Backend) BindFlags(flags *pflag.FlagSet) {
@@ -72,6 +73,15 @@ func (s *Backend) Sync() {
 		go impl.sync()
 	}
 	wg.Wait()
+
+	var err error
+	for _, impl := range IptablesImpl {
+		if impl.lastSyncError != nil {
+			err = impl.lastSyncError
+			break
+		}
+	}
+	s.SetApplyResult(err)
 }
 
 func (s *Backend) SetService(svc *localnetv1.Service) {
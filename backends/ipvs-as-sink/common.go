@@ -65,6 +65,8 @@ type endPointInfo struct {
 	endPointIP      string
 	isLocalEndPoint bool
 	portMap         map[string]int32
+	// weight of the endpoint (0 to use the default weight)
+	weight int32
 }
 
 func asDummyIPs(ip string, ipFamily v1.IPFamily) string {
@@ -244,6 +246,14 @@ func getIPSetEntry(srcAddr string, port *BaseServicePortInfo) *ipsetutil.Entry {
 
 func getIPFamiliesOfService(svc *localnetv1.Service) []v1.IPFamily {
 	var ipFamilies []v1.IPFamily
+	if len(svc.IPFamilies) != 0 {
+		for _, family := range svc.IPFamilies {
+			ipFamilies = append(ipFamilies, v1.IPFamily(family))
+		}
+		return ipFamilies
+	}
+
+	// not provided by the server, guess from the cluster IPs
 	if len(svc.IPs.ClusterIPs.V4) != 0 {
 		ipFamilies = append(ipFamilies, v1.IPv4Protocol)
 	}
@@ -343,12 +353,14 @@ func getNodePortIPSetEntry(port int, protocol string, ipSetType ipsetutil.Type)
 	}
 }
 
-func (p *proxier) sync() {
+func (p *proxier) sync() (err error) {
 	// sync iptable rules
-	p.syncIPTableRules()
+	err = p.syncIPTableRules()
 
 	// signal diffstores we've finished
 	p.endpoints.Reset(lightdiffstore.ItemUnchanged)
+
+	return
 }
 
 func (p *proxier) updateRefCountForIPSet(setName string, op Operation) {
@@ -416,6 +428,7 @@ func (p *proxier) addRealServer(serviceKey, prefix, endPointIP string, endpoint
 		endPointIP:      endPointIP,
 		isLocalEndPoint: endpoint.Local,
 		portMap:         make(map[string]int32),
+		weight:          endpoint.Weight,
 	}
 
 	for key, port := range endpoint.PortOverrides {
@@ -431,7 +444,12 @@ func (p *proxier) addRealServer(serviceKey, prefix, endPointIP string, endpoint
 			Dst: ipvsDestination(epInfo, &portInfo),
 		}
 		klog.V(2).Infof("adding destination ep (%v)", endPointIP)
-		if err := ipvs.AddDestination(dest.Svc, dest.Dst); err != nil && !strings.HasSuffix(err.Error(), "object exists") {
+		err := ipvs.AddDestination(dest.Svc, dest.Dst)
+		if err != nil && strings.HasSuffix(err.Error(), "object exists") {
+			// the endpoint was updated, its weight may have changed
+			err = ipvs.UpdateDestination(dest.Svc, dest.Dst)
+		}
+		if err != nil {
 			klog.Error("failed to add destination ", dest, ": ", err)
 		}
 	}
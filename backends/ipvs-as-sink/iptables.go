@@ -379,7 +379,7 @@ func (p *proxier) acceptIPVSTraffic() {
 	}
 }
 
-func (p *proxier) syncIPTableRules() {
+func (p *proxier) syncIPTableRules() error {
 	// Reset all buffers used later.
 	// This is to avoid memory reallocations and thus improve performance.
 	p.natChains.Reset()
@@ -409,6 +409,7 @@ func (p *proxier) syncIPTableRules() {
 	err := p.iptables.RestoreAll(p.iptablesData.Bytes(), util.NoFlushTables, util.RestoreCounters)
 	if err != nil {
 		klog.Error(err, "Failed to execute iptables-restore", "rules", string(p.iptablesData.Bytes()))
-		return
+		return fmt.Errorf("iptables-restore failed: %w", err)
 	}
+	return nil
 }
@@ -65,6 +65,7 @@ func init() {
 
 type Backend struct {
 	localsink.Config
+	localsink.Status
 	svcs     map[string]*localnetv1.Service
 	proxiers map[v1.IPFamily]*proxier
 	svcEPMap map[string]int
@@ -90,7 +91,7 @@ func New() *Backend {
 }
 
 func (s *Backend) Sink() localsink.Sink {
-	return filterreset.New(decoder.New(serviceevents.Wrap(s)))
+	return filterreset.New(decoder.New(decoder.InternalOnly(serviceevents.Wrap(s))))
 }
 
 // ------------------------------------------------------------------------
@@ -380,9 +381,13 @@ func (s *Backend) Sync() {
 		defer klog.Info("sync took ", time.Now().Sub(start))
 	}
 
+	var syncErr error
 	for _, proxier := range s.proxiers {
-		proxier.sync()
+		if err := proxier.sync(); err != nil && syncErr == nil {
+			syncErr = err
+		}
 	}
+	s.SetApplyResult(syncErr)
 }
 
 func (s *Backend) addServiceIPToKubeIPVSIntf(serviceIP string) {
@@ -84,9 +84,13 @@ func ipvsDestination(epInfo endPointInfo, port *BaseServicePortInfo) ipvs.Destin
 	if port.targetPort == 0 {
 		targetPort = epInfo.portMap[port.TargetPortName()]
 	}
+	weight := port.weight
+	if epInfo.weight > 0 {
+		weight = epInfo.weight
+	}
 	return ipvs.Destination{
 		Address: net.ParseIP(epInfo.endPointIP),
 		Port:    uint16(targetPort),
-		Weight:  port.weight,
+		Weight:  weight,
 	}
 }
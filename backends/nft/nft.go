@@ -53,6 +53,9 @@ var (
 	fullResync = true
 
 	hasNFTHashBug = false
+
+	// lastApplyError is the error of the last Callback call (nil if it succeeded)
+	lastApplyError error
 )
 
 func BindFlags(flags *pflag.FlagSet) {
@@ -90,6 +93,8 @@ func PreRun() {
 }
 
 func Callback(ch <-chan *client.ServiceEndpoints) {
+	lastApplyError = nil
+
 	svcCount := 0
 	epCount := 0
 
@@ -113,6 +118,10 @@ func Callback(ch <-chan *client.ServiceEndpoints) {
 			continue
 		}
 
+		if serviceEndpoints.Service.IPs.Headless {
+			continue
+		}
+
 		svcCount++
 
 		for _, ctx := range renderContexts {
@@ -155,6 +164,7 @@ func Callback(ch <-chan *client.ServiceEndpoints) {
 
 		if err != nil {
 			klog.Errorf("nft failed: %v (%s)", err, elapsed)
+			lastApplyError = fmt.Errorf("nft failed: %w", err)
 
 			// ensure render is finished
 			io.Copy(ioutil.Discard, cmdIn)
@@ -17,8 +17,12 @@ limitations under the License.
 package nft
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/pflag"
 
+	"sigs.k8s.io/kpng/client"
 	"sigs.k8s.io/kpng/client/backendcmd"
 	"sigs.k8s.io/kpng/client/localsink"
 	"sigs.k8s.io/kpng/client/localsink/fullstate"
@@ -45,10 +49,38 @@ func (b *backend) Sink() localsink.Sink {
 	PreRun()
 
 	ct := conntrack.New()
-	sink.Callback = fullstatepipe.New(fullstatepipe.ParallelSendSequenceClose,
-		Callback,
-		ct.Callback,
+	sink.Callback = fullstatepipe.NewStages(fullstatepipe.ParallelSendSequenceClose,
+		fullstatepipe.Stage{
+			Name: "nft",
+			Func: func(ch <-chan *client.ServiceEndpoints) error {
+				return apply(sink, ch)
+			},
+		},
+		fullstatepipe.Stage{
+			// flows are only cleaned up once the rules are applied
+			Name:     "conntrack",
+			Func:     fullstatepipe.WithoutError(ct.Callback),
+			Timeout:  time.Minute,
+			Requires: []string{"nft"},
+		},
 	).Callback
 
 	return sink
 }
+
+// apply runs Callback, reporting its result. The rules may be partially applied if it panics, so a full
+// resync is forced on the next run before letting the panic through.
+func apply(status interface{ SetApplyResult(error, ...string) }, ch <-chan *client.ServiceEndpoints) error {
+	defer func() {
+		if r := recover(); r != nil {
+			fullResync = true
+			lastApplyError = fmt.Errorf("panic: %v", r)
+			status.SetApplyResult(lastApplyError)
+			panic(r)
+		}
+	}()
+
+	Callback(ch)
+	status.SetApplyResult(lastApplyError)
+	return lastApplyError
+}
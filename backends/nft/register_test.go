@@ -0,0 +1,52 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nft
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kpng/client"
+	"sigs.k8s.io/kpng/client/localsink"
+)
+
+func TestApplyPanic(t *testing.T) {
+	defer func() { fullResync = true }()
+	fullResync = false
+
+	// an invalid entry (without service) makes Callback panic
+	ch := make(chan *client.ServiceEndpoints, 1)
+	ch <- &client.ServiceEndpoints{}
+	close(ch)
+
+	status := &localsink.Status{}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the panic to go through")
+			}
+		}()
+		apply(status, ch)
+	}()
+
+	if !fullResync {
+		t.Error("expected a full resync to be forced")
+	}
+	if s := status.ApplyStatus(); s == nil || s.Error == "" {
+		t.Errorf("expected the failure to be reported, got %v", s)
+	}
+}
@@ -71,7 +71,7 @@ func (ctx *renderContext) addServiceEndpoints(serviceEndpoints *fullstate.Servic
 	//iptype := table.nftIPType()
 
 	svc := serviceEndpoints.Service
-	endpoints := serviceEndpoints.Endpoints
+	endpoints := serviceEndpoints.InternalEndpoints()
 
 	// write endpoint chains
 	endpointIPs := ctx.epIPs(endpoints)
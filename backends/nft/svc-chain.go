@@ -101,21 +101,60 @@ func (ctx *renderContext) addSvcChain(svc *localnetv1.Service, epIPs []EpIP) {
 	}
 }
 
+// maxVmapSlots is the number of slots above which the endpoint weights are scaled down
+const maxVmapSlots = 1024
+
 func (ctx *renderContext) writeEndpointsVmap(w writer, svc *localnetv1.Service, epIPs []EpIP) {
+	// each endpoint gets as many slots as its weight (reduced by their GCD)
+	weights := make([]int, len(epIPs))
+	gcd, total := 0, 0
+	for i, epIP := range epIPs {
+		weights[i] = int(epIP.Endpoint.EffectiveWeight())
+		gcd = weightsGCD(gcd, weights[i])
+	}
+	for i := range weights {
+		weights[i] /= gcd
+		total += weights[i]
+	}
+
+	if total > maxVmapSlots && total > len(weights) {
+		// too many slots, scale the weights down (keeping at least one slot per endpoint)
+		scaled := 0
+		for i := range weights {
+			weights[i] = weights[i] * maxVmapSlots / total
+			if weights[i] == 0 {
+				weights[i] = 1
+			}
+			scaled += weights[i]
+		}
+		total = scaled
+	}
+
 	w.WriteString("numgen random mod ")
-	w.WriteString(strconv.Itoa(len(epIPs)))
+	w.WriteString(strconv.Itoa(total))
 	w.WriteString(" vmap {")
+	slot := 0
 	for i, epIP := range epIPs {
-		if i == 0 {
-			w.WriteString("\n    ")
-		} else if i%5 == 0 {
-			w.WriteString(",\n    ")
-		} else {
-			w.WriteString(", ")
+		for n := 0; n < weights[i]; n++ {
+			if slot == 0 {
+				w.WriteString("\n    ")
+			} else if slot%5 == 0 {
+				w.WriteString(",\n    ")
+			} else {
+				w.WriteString(", ")
+			}
+			w.WriteString(strconv.Itoa(nftKey(slot)))
+			w.WriteString(": jump ")
+			w.WriteString(ctx.epChainName(svc, epIP.Endpoint))
+			slot++
 		}
-		w.WriteString(strconv.Itoa(nftKey(i)))
-		w.WriteString(": jump ")
-		w.WriteString(ctx.epChainName(svc, epIP.Endpoint))
 	}
 	w.WriteString(" }\n")
 }
+
+func weightsGCD(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
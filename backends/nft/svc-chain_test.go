@@ -16,7 +16,12 @@ limitations under the License.
 
 package nft
 
-import "os"
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
 
 func ExampleSvcVmap() {
 	ctx, seps := testValues()
@@ -32,6 +37,43 @@ func ExampleSvcVmap() {
 	// }
 }
 
+func Example_svcVmapWeighted() {
+	ctx, seps := testValues()
+	seps.Endpoints[0].Weight = 4
+	seps.Endpoints[1].Weight = 2
+	ctx.addSvcVmap("my-vmap", seps.Service, ctx.epIPs(seps.Endpoints))
+	printTable(os.Stdout, ctx)
+
+	// Output:
+	// table ip k8s_svc {
+	//  chain my-vmap {
+	//   numgen random mod 7 vmap {
+	//     0: jump svc_my-ns_my-svc_ep_0a010001, 1: jump svc_my-ns_my-svc_ep_0a010001, 2: jump svc_my-ns_my-svc_ep_0a010001, 3: jump svc_my-ns_my-svc_ep_0a010001, 4: jump svc_my-ns_my-svc_ep_0a010002,
+	//     5: jump svc_my-ns_my-svc_ep_0a010002, 6: jump svc_my-ns_my-svc_ep_0a010101 }
+	//  }
+	// }
+}
+
+func TestSvcVmapMaxSlots(t *testing.T) {
+	ctx, seps := testValues()
+	seps.Endpoints[0].Weight = 2000
+	seps.Endpoints[1].Weight = 1000
+
+	buf := &bytes.Buffer{}
+	ctx.writeEndpointsVmap(buf, seps.Service, ctx.epIPs(seps.Endpoints))
+	vmap := buf.String()
+
+	if !strings.HasPrefix(vmap, "numgen random mod 1024 vmap") {
+		t.Errorf("expected 1024 slots, got %q...", vmap[:40])
+	}
+
+	for ep, expected := range map[string]int{"0a010001": 682, "0a010002": 341, "0a010101": 1} {
+		if n := strings.Count(vmap, "jump svc_my-ns_my-svc_ep_"+ep); n != expected {
+			t.Errorf("endpoint %s: expected %d slots, got %d", ep, expected, n)
+		}
+	}
+}
+
 func ExampleSvcChain() {
 	ctx, seps := testValues()
 	ctx.addSvcChain(seps.Service, ctx.epIPs(seps.Endpoints))
@@ -58,7 +58,7 @@ func New() *Backend {
 }
 
 func (s *Backend) Sink() localsink.Sink {
-	return filterreset.New(decoder.New(s))
+	return filterreset.New(decoder.New(decoder.InternalOnly(s)))
 }
 
 func (s *Backend) BindFlags(flags *pflag.FlagSet) {
@@ -104,7 +104,7 @@ func New() *Backend {
 }
 
 func (s *Backend) Sink() localsink.Sink {
-	return filterreset.New(decoder.New(serviceevents.Wrap(s)))
+	return filterreset.New(decoder.New(decoder.InternalOnly(serviceevents.Wrap(s))))
 }
 
 func (s *Backend) DeleteEndpoint(namespace, serviceName, key string) {
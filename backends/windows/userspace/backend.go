@@ -65,7 +65,7 @@ func (b *userspaceBackend) BindFlags(flags *pflag.FlagSet) {
 }
 
 func (b *userspaceBackend) Sink() localsink.Sink {
-	return filterreset.New(decoder.New(b))
+	return filterreset.New(decoder.New(decoder.InternalOnly(b)))
 }
 
 func (b *userspaceBackend) Setup() {
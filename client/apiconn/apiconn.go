@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apiconn provides the connection handling shared by the API clients: dial options with
+// keepalives, ordered targets with failover and fail-back, retry backoff and stall detection.
+package apiconn
+
+import (
+	"crypto/tls"
+	"math/rand"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+// DialOptions returns the gRPC dial options to reach an API server. The keepalive pings are
+// disabled if keepaliveTime is 0 (the server must permit them, see ServerOptions).
+func DialOptions(tlsCfg *tls.Config, maxMsgSize int, keepaliveTime time.Duration) (opts []grpc.DialOption) {
+	if tlsCfg == nil {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
+	}
+
+	if maxMsgSize > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMsgSize)))
+	}
+
+	if keepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                keepaliveTime,
+			PermitWithoutStream: true,
+		}))
+	}
+
+	return
+}
+
+// MinKeepaliveTime is the minimum interval of keepalive pings accepted by the servers (gRPC clients
+// can't ping more often anyway).
+const MinKeepaliveTime = 10 * time.Second
+
+// ServerOptions returns the gRPC server options permitting the clients' keepalive pings.
+func ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             MinKeepaliveTime,
+			PermitWithoutStream: true,
+		}),
+	}
+}
+
+// Targets is an ordered list of API servers: the next one is used when the current one fails,
+// and the first one is the preferred one to fail back to.
+type Targets struct {
+	targets []string
+	current int
+	since   time.Time
+}
+
+// ParseTargets parses a comma separated list of targets (a "multi:///" prefix is accepted).
+func ParseTargets(spec string) *Targets {
+	spec = strings.TrimPrefix(spec, "multi:///")
+
+	t := &Targets{}
+	for _, target := range strings.Split(spec, ",") {
+		if target = strings.TrimSpace(target); target != "" {
+			t.targets = append(t.targets, target)
+		}
+	}
+
+	if len(t.targets) == 0 {
+		t.targets = []string{""}
+	}
+
+	return t
+}
+
+// Current returns the target to use.
+func (t *Targets) Current() string {
+	return t.targets[t.current]
+}
+
+// Failed switches to the next target, returning true when all the targets have been tried
+// (the caller should then wait before retrying).
+func (t *Targets) Failed() (allFailed bool) {
+	t.current = (t.current + 1) % len(t.targets)
+	t.since = time.Time{}
+	return t.current == 0
+}
+
+// Connected records that the current target is working (since its first call).
+func (t *Targets) Connected() {
+	if t.since.IsZero() {
+		t.since = time.Now()
+	}
+}
+
+// FailBackDue returns true if a fallback target has been used for at least the given delay
+// (never if delay is 0).
+func (t *Targets) FailBackDue(delay time.Duration) bool {
+	return delay > 0 && t.current != 0 && !t.since.IsZero() && time.Since(t.since) >= delay
+}
+
+// FailBack switches back to the preferred target.
+func (t *Targets) FailBack() {
+	t.current = 0
+	t.since = time.Time{}
+}
+
+// Backoff computes the delays between retries: exponential from Initial up to Max, with jitter.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+
+	failures int
+}
+
+// Next returns the delay to wait before the next retry.
+func (b *Backoff) Next() time.Duration {
+	d := b.Initial
+	for i := 0; i < b.failures && d < b.Max; i++ {
+		d *= 2
+	}
+
+	if d > b.Max && b.Max > b.Initial {
+		d = b.Max
+	}
+
+	b.failures++
+
+	if d <= 0 {
+		return 0
+	}
+
+	// "equal jitter": between d/2 and d, so the clients don't retry all at once
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// Reset resets the delay to its initial value, after a success.
+func (b *Backoff) Reset() {
+	b.failures = 0
+}
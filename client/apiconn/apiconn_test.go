@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiconn
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+)
+
+func ExampleTargets() {
+	t := ParseTargets("multi:///a:1234, b:1234")
+
+	fmt.Println(t.Current())
+	fmt.Println(t.Failed(), t.Current())
+	fmt.Println(t.Failed(), t.Current())
+
+	t.Failed()
+	t.Connected()
+	fmt.Println(t.FailBackDue(0), t.FailBackDue(time.Nanosecond))
+
+	t.FailBack()
+	fmt.Println(t.Current())
+
+	// Output:
+	// a:1234
+	// false b:1234
+	// true a:1234
+	// false true
+	// a:1234
+}
+
+func TestBackoff(t *testing.T) {
+	b := Backoff{Initial: 100 * time.Millisecond, Max: time.Second}
+
+	for i, max := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		max *= time.Millisecond
+
+		if d := b.Next(); d < max/2 || d > max {
+			t.Errorf("retry %d: delay %v not in [%v, %v]", i, d, max/2, max)
+		}
+	}
+
+	b.Reset()
+	if d := b.Next(); d > b.Initial {
+		t.Errorf("delay %v after reset is over %v", d, b.Initial)
+	}
+}
+
+func TestWatchdog(t *testing.T) {
+	w := Watchdog{Timeout: 10 * time.Millisecond}
+
+	set := &localnetv1.OpItem{Op: &localnetv1.OpItem_Set{}}
+	sync := &localnetv1.OpItem{Op: &localnetv1.OpItem_Sync{}}
+
+	ctx := w.Context(context.Background())
+	w.Received(set)
+	w.Received(sync)
+
+	time.Sleep(20 * time.Millisecond)
+	if ctx.Err() != nil || w.Stalled() {
+		t.Fatal("synced stream canceled")
+	}
+
+	w.Received(set)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("stalled stream not canceled")
+	}
+
+	if !w.Stalled() {
+		t.Error("stalled stream not reported")
+	}
+
+	w.Context(context.Background())
+	if w.Stalled() {
+		t.Error("new stream reported as stalled")
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiconn
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/client/tlsflags"
+)
+
+// ErrFailBack is returned by the watches leaving a fallback server for the preferred one.
+var ErrFailBack = errors.New("failing back to the preferred API server")
+
+// Watch handles the connections of a watch to the remote API: failover between the servers,
+// retries with backoff, keepalives and stall detection.
+type Watch struct {
+	// Server is the API server, or a comma separated list of servers in order of preference
+	Server   string
+	TLSFlags *tlsflags.Flags
+
+	// ErrorDelay is the delay before retrying after an error, doubled after each error up to MaxErrorDelay (1s if 0)
+	ErrorDelay    time.Duration
+	MaxErrorDelay time.Duration
+
+	// KeepaliveTime is the interval of the keepalive pings (disabled if 0)
+	KeepaliveTime time.Duration
+
+	// SyncTimeout is the maximum time to receive a change set before reconnecting (disabled if 0)
+	SyncTimeout time.Duration
+
+	// FailBackDelay is the time after which a fallback server is left for the first one (disabled if 0)
+	FailBackDelay time.Duration
+
+	// MaxMsgSize is the max size of a gRPC message (gRPC's default if 0)
+	MaxMsgSize int
+
+	targets  *Targets
+	backoff  Backoff
+	watchdog Watchdog
+}
+
+// Targets returns the servers to connect to.
+func (w *Watch) Targets() *Targets {
+	if w.targets == nil {
+		w.targets = ParseTargets(w.Server)
+	}
+	return w.targets
+}
+
+// Dial connects to the current API server.
+func (w *Watch) Dial() (conn *grpc.ClientConn, err error) {
+	return w.DialContext(context.Background())
+}
+
+// DialContext connects to the current API server, using the given context.
+func (w *Watch) DialContext(ctx context.Context) (conn *grpc.ClientConn, err error) {
+	target := w.Targets().Current()
+
+	klog.Info("connecting to ", target)
+
+	return grpc.DialContext(ctx, target, DialOptions(w.TLSFlags.Config(), w.MaxMsgSize, w.KeepaliveTime)...)
+}
+
+// StreamContext returns the context of a new watch stream, canceled if the stream stalls.
+func (w *Watch) StreamContext(ctx context.Context) context.Context {
+	w.watchdog.Timeout = w.SyncTimeout
+	return w.watchdog.Context(ctx)
+}
+
+// Received must be called with each operation received on the watch stream.
+func (w *Watch) Received(op *localnetv1.OpItem) {
+	w.watchdog.Received(op)
+}
+
+// Stalled returns true if the current stream's context was canceled because it stalled.
+func (w *Watch) Stalled() bool {
+	return w.watchdog.Stalled()
+}
+
+// Stop releases the current stream's context.
+func (w *Watch) Stop() {
+	w.watchdog.Stop()
+}
+
+// Synced must be called after each change set is applied. It returns ErrFailBack when the watch
+// must be restarted to fail back to the preferred server.
+func (w *Watch) Synced() error {
+	w.backoff.Reset()
+
+	targets := w.Targets()
+	targets.Connected()
+
+	if targets.FailBackDue(w.FailBackDelay) {
+		klog.Info("failing back from ", targets.Current())
+		targets.FailBack()
+		return ErrFailBack
+	}
+
+	return nil
+}
+
+// Retry must be called after a watch error: it switches to the next server, waiting before retrying
+// when all the servers failed.
+func (w *Watch) Retry(ctx context.Context, err error) {
+	w.watchdog.Stop()
+
+	if errors.Is(err, ErrFailBack) {
+		return
+	}
+
+	if w.watchdog.Stalled() {
+		klog.Warning("no change set received within ", w.SyncTimeout, ", reconnecting")
+	}
+
+	if !w.Targets().Failed() {
+		return
+	}
+
+	w.backoff.Initial, w.backoff.Max = w.ErrorDelay, w.MaxErrorDelay
+	if w.backoff.Initial <= 0 {
+		w.backoff.Initial = time.Second
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(w.backoff.Next()):
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiconn
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+)
+
+// Watchdog detects the watch streams stalled in the middle of a change set (ie: half-open connections
+// or stuck servers), canceling their context when a change set is not synced within Timeout.
+// A stream waiting for changes is not considered stalled (the keepalives protect it).
+type Watchdog struct {
+	// Timeout to receive a Sync after the first operation of a change set (disabled if 0)
+	Timeout time.Duration
+
+	mu      sync.Mutex
+	cancel  func()
+	timer   *time.Timer
+	stalled bool
+}
+
+// Context returns the context of a new watch stream, replacing the previous one.
+func (w *Watchdog) Context(parent context.Context) (ctx context.Context) {
+	w.Stop()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ctx, w.cancel = context.WithCancel(parent)
+	w.stalled = false
+	return
+}
+
+// Received must be called with each operation received on the stream.
+func (w *Watchdog) Received(op *localnetv1.OpItem) {
+	if w.Timeout <= 0 {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, isSync := op.Op.(*localnetv1.OpItem_Sync); isSync {
+		if w.timer != nil {
+			w.timer.Stop()
+			w.timer = nil
+		}
+		return
+	}
+
+	if w.timer == nil {
+		cancel := w.cancel
+		w.timer = time.AfterFunc(w.Timeout, func() {
+			w.mu.Lock()
+			w.stalled = true
+			w.mu.Unlock()
+
+			cancel()
+		})
+	}
+}
+
+// Stalled returns true if the current stream's context was canceled because it stalled.
+func (w *Watchdog) Stalled() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.stalled
+}
+
+// Stop releases the current stream's context and timer.
+func (w *Watchdog) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+
+	if w.cancel != nil {
+		w.cancel()
+		w.cancel = nil
+	}
+}
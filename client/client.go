@@ -24,14 +24,11 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 
 	"k8s.io/klog/v2"
 
-	// allow multi gRPC URLs
-	//_ "github.com/Jille/grpc-multi-resolver"
-
 	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/client/apiconn"
 	"sigs.k8s.io/kpng/client/localsink"
 	"sigs.k8s.io/kpng/client/localsink/fullstate"
 	"sigs.k8s.io/kpng/client/tlsflags"
@@ -54,35 +51,65 @@ func New(flags FlagSet) (epc *EndpointsClient) {
 
 // EndpointsClient is a simple client to kube-proxy's Endpoints API.
 type EndpointsClient struct {
-	// Target is the gRPC dial target
+	// Target is the gRPC dial target; it can be a comma separated list of targets in order of
+	// preference, the next one being used when the current one fails.
 	Target string
 
 	TLS *tlsflags.Flags
 
-	// ErrorDelay is the delay before retrying after an error.
-	ErrorDelay time.Duration
+	// ErrorDelay is the delay before retrying after an error, doubled after each error up to MaxErrorDelay.
+	ErrorDelay    time.Duration
+	MaxErrorDelay time.Duration
+
+	// KeepaliveTime is the interval of the keepalive pings (disabled if 0)
+	KeepaliveTime time.Duration
+
+	// SyncTimeout is the maximum time to receive a change set before reconnecting (disabled if 0)
+	SyncTimeout time.Duration
+
+	// FailBackDelay is the time after which a fallback target is left for the first one (disabled if 0)
+	FailBackDelay time.Duration
 
 	// GRPCBuffer is the max size of a gRPC message
 	MaxMsgSize int
 
 	Sink localsink.Sink
 
+	// Filter restricts the watched services (everything is watched if nil)
+	Filter *localnetv1.WatchFilter
+
+	filterFlags watchFilterFlags
+
 	conn     *grpc.ClientConn
 	watch    localnetv1.Endpoints_WatchClient
 	watchReq *localnetv1.WatchReq
 
+	// api handles the connections, configured from the fields above
+	api apiconn.Watch
+
+	// last revision fully received, to resume after reconnections
+	lastRev      *localnetv1.Revision
+	lastNodeName string
+
 	ctx    context.Context
 	cancel func()
 }
 
 // DefaultFlags registers this client's values to the standard flags.
 func (epc *EndpointsClient) DefaultFlags(flags FlagSet) {
-	flags.StringVar(&epc.Target, "api", "127.0.0.1:12090", "API to reach (can use multi:///1.0.0.1:1234,1.0.0.2:1234)")
+	flags.StringVar(&epc.Target, "api", "127.0.0.1:12090", "API to reach (can be a comma separated list, in order of preference)")
 
-	flags.DurationVar(&epc.ErrorDelay, "error-delay", 1*time.Second, "duration to wait before retrying after errors")
+	flags.DurationVar(&epc.ErrorDelay, "error-delay", 1*time.Second, "duration to wait before retrying after errors (doubled after each error)")
+	flags.DurationVar(&epc.MaxErrorDelay, "max-error-delay", 30*time.Second, "maximum duration to wait before retrying after errors")
+
+	flags.DurationVar(&epc.KeepaliveTime, "keepalive", 30*time.Second, "interval of the keepalive pings to the API (0 to disable)")
+	flags.DurationVar(&epc.SyncTimeout, "sync-timeout", time.Minute, "maximum duration to receive a change set before reconnecting (0 to disable)")
+	flags.DurationVar(&epc.FailBackDelay, "fail-back-delay", 5*time.Minute, "duration after which a fallback API is left for the first one (0 to disable)")
 
 	flags.IntVar(&epc.MaxMsgSize, "max-msg-size", 4<<20, "max gRPC message size")
 
+	epc.filterFlags.bind(flags)
+
 	epc.TLS.Bind(flags, "")
 }
 
@@ -106,11 +133,26 @@ retry:
 		return
 	}
 
-	err = epc.watch.Send(&localnetv1.WatchReq{
+	req := &localnetv1.WatchReq{
 		NodeName: nodeName,
-	})
+		Filter:   epc.WatchFilter(),
+	}
+
+	if nodeName == epc.lastNodeName {
+		req.LastRevision = epc.lastRev
+	}
+
+	if epc.lastRev != nil {
+		// report the result of the last apply, if the sink tracks it
+		if status := localsink.ApplyStatusOf(epc.Sink); status != nil {
+			status.Revision = epc.lastRev
+			req.ApplyStatus = status
+		}
+	}
+
+	err = epc.watch.Send(req)
 	if err != nil {
-		epc.postError()
+		epc.postError(err)
 		goto retry
 	}
 
@@ -118,18 +160,36 @@ retry:
 		op, err := epc.watch.Recv()
 
 		if err != nil {
-			// klog.Error("watch recv failed: ", err)
-			epc.postError()
+			epc.postError(err)
 			goto retry
 		}
 
-		// pass the op to the sync
-		epc.Sink.Send(op)
+		epc.apiWatch().Received(op)
+
+		// the sink is receiving a change set, its state won't match a revision until the sync
+		epc.lastRev = nil
 
-		// break on sync
 		switch v := op.Op; v.(type) {
+		case *localnetv1.OpItem_Reset_:
+			// the server could not resume from our last revision
+			epc.Sink.Reset()
+
 		case *localnetv1.OpItem_Sync:
+			epc.Sink.Send(op)
+
+			epc.lastRev = op.Revision
+			epc.lastNodeName = nodeName
+
+			if err := epc.apiWatch().Synced(); err == apiconn.ErrFailBack {
+				epc.closeWatch() // the next call reconnects
+			}
+
+			// break on sync
 			return
+
+		default:
+			// pass the op to the sink
+			epc.Sink.Send(op)
 		}
 	}
 }
@@ -165,21 +225,22 @@ func (epc *EndpointsClient) Context() context.Context {
 }
 
 func (epc *EndpointsClient) DialContext(ctx context.Context) (conn *grpc.ClientConn, err error) {
-	klog.Info("connecting to ", epc.Target)
-
-	opts := append(
-		make([]grpc.DialOption, 0),
-		grpc.WithMaxMsgSize(epc.MaxMsgSize),
-	)
-
-	tlsCfg := epc.TLS.Config()
-	if tlsCfg == nil {
-		opts = append(opts, grpc.WithInsecure())
-	} else {
-		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)))
-	}
+	return epc.apiWatch().DialContext(ctx)
+}
+
+// apiWatch returns the connections handler, configured from the client's fields.
+func (epc *EndpointsClient) apiWatch() *apiconn.Watch {
+	w := &epc.api
 
-	return grpc.DialContext(epc.ctx, epc.Target, opts...)
+	w.Server = epc.Target
+	w.TLSFlags = epc.TLS
+	w.ErrorDelay, w.MaxErrorDelay = epc.ErrorDelay, epc.MaxErrorDelay
+	w.KeepaliveTime = epc.KeepaliveTime
+	w.SyncTimeout = epc.SyncTimeout
+	w.FailBackDelay = epc.FailBackDelay
+	w.MaxMsgSize = epc.MaxMsgSize
+
+	return w
 }
 
 func (epc *EndpointsClient) Dial() (conn *grpc.ClientConn, err error) {
@@ -199,46 +260,48 @@ retry:
 		return true
 	} else if err != nil {
 		//klog.Info("failed to connect: ", err)
-		epc.errorSleep()
+		epc.apiWatch().Retry(epc.ctx, err)
 		goto retry
 	}
 
 	epc.conn = conn
-	epc.watch, err = localnetv1.NewEndpointsClient(epc.conn).Watch(epc.ctx)
+	epc.watch, err = localnetv1.NewEndpointsClient(epc.conn).Watch(epc.apiWatch().StreamContext(epc.ctx))
 
 	if err != nil {
 		conn.Close()
 
 		//klog.Info("failed to start watch: ", err)
-		epc.errorSleep()
+		epc.apiWatch().Retry(epc.ctx, err)
 		goto retry
 	}
 
-	epc.Sink.Reset()
+	// no sink reset here: the server will send one if it can't resume from our last revision
 
 	//klog.V(1).Info("connected")
 	return false
 }
 
-func (epc *EndpointsClient) errorSleep() {
-	time.Sleep(epc.ErrorDelay)
-}
-
-func (epc *EndpointsClient) postError() {
+func (epc *EndpointsClient) closeWatch() {
 	if epc.watch != nil {
 		epc.watch.CloseSend()
 		epc.watch = nil
 	}
 
+	epc.api.Stop()
+
 	if epc.conn != nil {
 		epc.conn.Close()
 		epc.conn = nil
 	}
+}
+
+func (epc *EndpointsClient) postError(err error) {
+	epc.closeWatch()
 
 	if err := epc.ctx.Err(); err != nil {
 		return
 	}
 
-	epc.errorSleep()
+	epc.apiWatch().Retry(epc.ctx, err)
 	epc.dial()
 }
@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/client/localsink"
+)
+
+// stallingEndpointsServer stalls in the middle of the first change set, and sends a full one on the next watches.
+type stallingEndpointsServer struct {
+	localnetv1.UnimplementedEndpointsServer
+	watches int32
+}
+
+func (s *stallingEndpointsServer) Watch(w localnetv1.Endpoints_WatchServer) error {
+	stall := atomic.AddInt32(&s.watches, 1) == 1
+
+	if _, err := w.Recv(); err != nil {
+		return err
+	}
+
+	b, _ := proto.Marshal(&localnetv1.Service{Namespace: "ns", Name: "svc-1"})
+
+	for _, err := range []error{
+		w.Send(&localnetv1.OpItem{Op: &localnetv1.OpItem_Reset_{}}),
+		w.Send(&localnetv1.OpItem{Op: &localnetv1.OpItem_Set{Set: &localnetv1.Value{
+			Ref:   &localnetv1.Ref{Set: localnetv1.Set_ServicesSet, Path: "ns/svc-1"},
+			Bytes: b,
+		}}}),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	if stall {
+		<-w.Context().Done()
+		return nil
+	}
+
+	if err := w.Send(&localnetv1.OpItem{Op: &localnetv1.OpItem_Sync{}, Revision: &localnetv1.Revision{Rev: 1}}); err != nil {
+		return err
+	}
+
+	// wait for the client to leave
+	_, err := w.Recv()
+	return err
+}
+
+// opsSink records the operations received
+type opsSink struct {
+	localsink.Config
+	ops []string
+}
+
+func (s *opsSink) Setup() {}
+
+func (s *opsSink) Reset() {
+	s.ops = append(s.ops, "reset")
+}
+
+func (s *opsSink) Send(op *localnetv1.OpItem) error {
+	switch v := op.Op.(type) {
+	case *localnetv1.OpItem_Set:
+		s.ops = append(s.ops, "set "+v.Set.Ref.Path)
+	case *localnetv1.OpItem_Sync:
+		s.ops = append(s.ops, "sync")
+	}
+	return nil
+}
+
+func TestEndpointsClientStalled(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := grpc.NewServer()
+	server := &stallingEndpointsServer{}
+	localnetv1.RegisterEndpointsServer(srv, server)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	sink := &opsSink{Config: localsink.Config{NodeName: "node-a"}}
+
+	epc := New(pflag.NewFlagSet("test", pflag.ContinueOnError))
+	epc.Target = lis.Addr().String()
+	epc.ErrorDelay = 10 * time.Millisecond
+	epc.SyncTimeout = 100 * time.Millisecond
+	epc.Sink = sink
+	defer epc.Cancel()
+
+	done := make(chan bool, 1)
+	go func() { done <- epc.Next() }()
+
+	select {
+	case canceled := <-done:
+		if canceled {
+			t.Fatal("unexpected cancel")
+		}
+	case <-time.After(5 * time.Second):
+		epc.Cancel()
+		<-done
+		t.Fatal("the stalled watch was not restarted")
+	}
+
+	if ops := fmt.Sprint(sink.ops); ops != "[reset set ns/svc-1 reset set ns/svc-1 sync]" {
+		t.Errorf("unexpected ops: %s", ops)
+	}
+	if watches := atomic.LoadInt32(&server.watches); watches != 2 {
+		t.Errorf("expected 2 watches, got %d", watches)
+	}
+}
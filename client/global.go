@@ -0,0 +1,228 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/client/apiconn"
+	"sigs.k8s.io/kpng/client/globalsink"
+	"sigs.k8s.io/kpng/client/tlsflags"
+)
+
+// NewGlobal returns a new GlobalClient with values bound to the given flag-set for command-line tools.
+// Other needs can use `&GlobalClient{...}` directly.
+func NewGlobal(flags FlagSet) (gc *GlobalClient) {
+	gc = &GlobalClient{
+		TLS: &tlsflags.Flags{},
+	}
+	gc.DefaultFlags(flags)
+	return
+}
+
+// GlobalClient is a simple client to kube-proxy's Global API, giving the cluster-wide state to a
+// globalsink.Sink (see the globalsink decoder and fullstate packages).
+type GlobalClient struct {
+	// Target is the gRPC dial target; it can be a comma separated list of targets in order of
+	// preference, the next one being used when the current one fails.
+	Target string
+
+	TLS *tlsflags.Flags
+
+	// ErrorDelay is the delay before retrying after an error, doubled after each error up to MaxErrorDelay (1s if 0).
+	ErrorDelay    time.Duration
+	MaxErrorDelay time.Duration
+
+	// KeepaliveTime is the interval of the keepalive pings (disabled if 0)
+	KeepaliveTime time.Duration
+
+	// SyncTimeout is the maximum time to receive a change set before reconnecting (disabled if 0)
+	SyncTimeout time.Duration
+
+	// FailBackDelay is the time after which a fallback target is left for the first one (disabled if 0)
+	FailBackDelay time.Duration
+
+	// MaxMsgSize is the max size of a gRPC message
+	MaxMsgSize int
+
+	// Sets to watch, in GlobalServiceInfos, GlobalEndpointInfos and GlobalNodeInfos (all if empty)
+	Sets []localnetv1.Set
+
+	// Filter on the services to watch (all if nil)
+	Filter *localnetv1.WatchFilter
+
+	Sink globalsink.Sink
+
+	setsFlag    string
+	filterFlags watchFilterFlags
+
+	// api handles the connections, configured from the fields above
+	api apiconn.Watch
+
+	// last revision fully received, to resume after reconnections
+	lastRev *localnetv1.Revision
+}
+
+// DefaultFlags registers this client's values to the standard flags.
+func (gc *GlobalClient) DefaultFlags(flags FlagSet) {
+	flags.StringVar(&gc.Target, "api", "127.0.0.1:12090", "API to reach (can be a comma separated list, in order of preference)")
+
+	flags.DurationVar(&gc.ErrorDelay, "error-delay", 1*time.Second, "duration to wait before retrying after errors (doubled after each error)")
+	flags.DurationVar(&gc.MaxErrorDelay, "max-error-delay", 30*time.Second, "maximum duration to wait before retrying after errors")
+
+	flags.DurationVar(&gc.KeepaliveTime, "keepalive", 30*time.Second, "interval of the keepalive pings to the API (0 to disable)")
+	flags.DurationVar(&gc.SyncTimeout, "sync-timeout", time.Minute, "maximum duration to receive a change set before reconnecting (0 to disable)")
+	flags.DurationVar(&gc.FailBackDelay, "fail-back-delay", 5*time.Minute, "duration after which a fallback API is left for the first one (0 to disable)")
+
+	flags.IntVar(&gc.MaxMsgSize, "max-msg-size", 4<<20, "max gRPC message size")
+
+	flags.StringVar(&gc.setsFlag, "watch-sets", "", "sets to watch (comma-separated, in GlobalServiceInfos, GlobalEndpointInfos and GlobalNodeInfos; all if not set)")
+	gc.filterFlags.bind(flags)
+
+	gc.TLS.Bind(flags, "")
+}
+
+// WatchFilter returns the filter to send with watch requests (nil if none).
+func (gc *GlobalClient) WatchFilter() *localnetv1.WatchFilter {
+	if gc.Filter != nil {
+		return gc.Filter
+	}
+	return gc.filterFlags.filter()
+}
+
+// WatchSets returns the sets to send with watch requests (all if empty).
+func (gc *GlobalClient) WatchSets() (sets []localnetv1.Set, err error) {
+	if len(gc.Sets) != 0 {
+		return gc.Sets, nil
+	}
+
+	for _, name := range splitList(gc.setsFlag) {
+		v, ok := localnetv1.Set_value[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown set: %s", name)
+		}
+		sets = append(sets, localnetv1.Set(v))
+	}
+
+	return
+}
+
+// Run sends the global state and its changes to the Sink until the context is canceled, reconnecting
+// after errors. It only fails when canceled or misconfigured.
+func (gc *GlobalClient) Run(ctx context.Context) (err error) {
+	sets, err := gc.WatchSets()
+	if err != nil {
+		return
+	}
+
+	gc.Sink.Setup()
+
+	for {
+		err = gc.watch(ctx, sets)
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err == apiconn.ErrFailBack {
+			klog.Info(err)
+		} else if !gc.api.Stalled() {
+			klog.Error("global watch error: ", err)
+		}
+
+		gc.api.Retry(ctx, err)
+	}
+}
+
+// apiWatch returns the connections handler, configured from the client's fields.
+func (gc *GlobalClient) apiWatch() *apiconn.Watch {
+	w := &gc.api
+
+	w.Server = gc.Target
+	w.TLSFlags = gc.TLS
+	w.ErrorDelay, w.MaxErrorDelay = gc.ErrorDelay, gc.MaxErrorDelay
+	w.KeepaliveTime = gc.KeepaliveTime
+	w.SyncTimeout = gc.SyncTimeout
+	w.FailBackDelay = gc.FailBackDelay
+	w.MaxMsgSize = gc.MaxMsgSize
+
+	return w
+}
+
+func (gc *GlobalClient) watch(ctx context.Context, sets []localnetv1.Set) (err error) {
+	api := gc.apiWatch()
+
+	conn, err := api.DialContext(ctx)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	watch, err := localnetv1.NewGlobalClient(conn).Watch(api.StreamContext(ctx))
+	if err != nil {
+		return
+	}
+	defer api.Stop()
+
+	for {
+		err = watch.Send(&localnetv1.GlobalWatchReq{
+			LastRevision: gc.lastRev,
+			Filter:       gc.WatchFilter(),
+			Sets:         sets,
+		})
+		if err != nil {
+			return
+		}
+
+		for {
+			var op *localnetv1.OpItem
+			op, err = watch.Recv()
+			if err != nil {
+				return
+			}
+
+			api.Received(op)
+
+			// the sink is receiving a change set, its state won't match a revision until the sync
+			gc.lastRev = nil
+
+			switch op.Op.(type) {
+			case *localnetv1.OpItem_Reset_:
+				gc.Sink.Reset()
+
+			default:
+				if err = gc.Sink.Send(op); err != nil {
+					return
+				}
+			}
+
+			if _, isSync := op.Op.(*localnetv1.OpItem_Sync); isSync {
+				gc.lastRev = op.Revision
+				break
+			}
+		}
+
+		if err = api.Synced(); err != nil {
+			return
+		}
+	}
+}
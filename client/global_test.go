@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/client/globalsink/decoder"
+	"sigs.k8s.io/kpng/client/globalsink/fullstate"
+)
+
+type testGlobalServer struct {
+	localnetv1.UnimplementedGlobalServer
+
+	// stalls is the number of watches to stall in the middle of the change set
+	stalls int32
+}
+
+func (s *testGlobalServer) Watch(w localnetv1.Global_WatchServer) error {
+	set := func(set localnetv1.Set, path string, v proto.Message) error {
+		b, _ := proto.Marshal(v)
+		return w.Send(&localnetv1.OpItem{Op: &localnetv1.OpItem_Set{Set: &localnetv1.Value{
+			Ref:   &localnetv1.Ref{Set: set, Path: path},
+			Bytes: b,
+		}}})
+	}
+
+	if _, err := w.Recv(); err != nil {
+		return err
+	}
+
+	ep := &localnetv1.EndpointInfo{Namespace: "ns", SourceName: "svc-1-abc", ServiceName: "svc-1",
+		Endpoint: &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.2.0.1")}}
+
+	if err := w.Send(&localnetv1.OpItem{Op: &localnetv1.OpItem_Reset_{}}); err != nil {
+		return err
+	}
+
+	if atomic.AddInt32(&s.stalls, -1) >= 0 {
+		<-w.Context().Done()
+		return nil
+	}
+
+	for _, err := range []error{
+		set(localnetv1.Set_GlobalNodeInfos, "|node-1||", &localnetv1.NodeInfo{Node: &localnetv1.Node{Name: "node-1"}}),
+		set(localnetv1.Set_GlobalServiceInfos, "ns|svc-1||", &localnetv1.ServiceInfo{Service: &localnetv1.Service{Namespace: "ns", Name: "svc-1"}}),
+		set(localnetv1.Set_GlobalEndpointInfos, "ns|svc-1|svc-1-abc|h1", ep),
+		set(localnetv1.Set_GlobalEndpointInfos, "ns||svc-1-abc|h1", ep), // indexed by source
+		w.Send(&localnetv1.OpItem{Op: &localnetv1.OpItem_Sync{}, Revision: &localnetv1.Revision{Rev: 1}}),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	// wait for the client to leave
+	_, err := w.Recv()
+	return err
+}
+
+func TestGlobalClient(t *testing.T) {
+	testGlobalClient(t, &testGlobalServer{}, &GlobalClient{})
+}
+
+func TestGlobalClientStalled(t *testing.T) {
+	testGlobalClient(t, &testGlobalServer{stalls: 1}, &GlobalClient{
+		ErrorDelay:  10 * time.Millisecond,
+		SyncTimeout: 100 * time.Millisecond,
+	})
+}
+
+func testGlobalClient(t *testing.T, server *testGlobalServer, gc *GlobalClient) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := grpc.NewServer()
+	localnetv1.RegisterGlobalServer(srv, server)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var state *fullstate.State
+
+	gc.Target = lis.Addr().String()
+	gc.Sink = decoder.New(fullstate.New(func(s *fullstate.State) {
+		state = s
+		cancel()
+	}))
+
+	if err := gc.Run(ctx); err != context.Canceled {
+		t.Fatal("unexpected error: ", err)
+	}
+
+	if state == nil {
+		t.Fatal("no state received")
+	}
+
+	for _, node := range state.Nodes {
+		t.Log("node: ", node.Node.Name)
+	}
+	for _, seps := range state.Services {
+		t.Log("service: ", seps.Service.Service.Name, ", endpoints: ", len(seps.Endpoints))
+	}
+
+	if len(state.Nodes) != 1 || len(state.Services) != 1 || len(state.Services[0].Endpoints) != 1 {
+		t.Errorf("unexpected state: %d nodes, %d services", len(state.Nodes), len(state.Services))
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package decoder decodes the global API's stream into typed calls, the cluster-wide counterpart
+// of the localsink decoder.
+package decoder
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/client/globalsink"
+)
+
+type ServicesListener interface {
+	// SetService is called when a service is added or updated
+	SetService(info *localnetv1.ServiceInfo)
+	// DeleteService is called when a service is deleted
+	DeleteService(namespace, name string)
+}
+
+type EndpointsListener interface {
+	// SetEndpoint is called when an endpoint is added or updated. The key is unique in the service
+	// (source/key, the source being the endpoints' source like an EndpointSlice).
+	SetEndpoint(namespace, serviceName, key string, info *localnetv1.EndpointInfo)
+	// DeleteEndpoint is called when an endpoint is deleted
+	DeleteEndpoint(namespace, serviceName, key string)
+}
+
+type NodesListener interface {
+	// SetNode is called when a node is added or updated
+	SetNode(info *localnetv1.NodeInfo)
+	// DeleteNode is called when a node is deleted
+	DeleteNode(name string)
+}
+
+// Interface is the receiver of the decoded values. It should implement the listeners of the sets
+// it watches (ServicesListener, EndpointsListener and/or NodesListener); values of other sets are
+// ignored.
+type Interface interface {
+	// Sync signals an stream sync event
+	Sync()
+
+	// Setup see globalsink.Sink#Setup
+	Setup()
+
+	// Reset see globalsink.Sink#Reset
+	Reset()
+}
+
+type Sink struct {
+	Interface
+}
+
+var _ globalsink.Sink = &Sink{}
+
+func New(iface Interface) *Sink {
+	return &Sink{iface}
+}
+
+func (s *Sink) Send(op *localnetv1.OpItem) (err error) {
+	switch op.Op.(type) {
+	case *localnetv1.OpItem_Set:
+		set := op.GetSet()
+		path := globalsink.ParsePath(set.Ref.Path)
+
+		switch set.Ref.Set {
+		case localnetv1.Set_GlobalServiceInfos:
+			l, ok := s.Interface.(ServicesListener)
+			if !ok {
+				return
+			}
+
+			v := &localnetv1.ServiceInfo{}
+			if err = proto.Unmarshal(set.Bytes, v); err != nil {
+				return
+			}
+
+			l.SetService(v)
+
+		case localnetv1.Set_GlobalEndpointInfos:
+			l, ok := s.Interface.(EndpointsListener)
+			if !ok || path.Name == "" { // endpoints are also indexed by source, without a service name
+				return
+			}
+
+			v := &localnetv1.EndpointInfo{}
+			if err = proto.Unmarshal(set.Bytes, v); err != nil {
+				return
+			}
+
+			l.SetEndpoint(path.Namespace, path.Name, path.Source+"/"+path.Key, v)
+
+		case localnetv1.Set_GlobalNodeInfos:
+			l, ok := s.Interface.(NodesListener)
+			if !ok {
+				return
+			}
+
+			v := &localnetv1.NodeInfo{}
+			if err = proto.Unmarshal(set.Bytes, v); err != nil {
+				return
+			}
+
+			l.SetNode(v)
+
+		default:
+			// unknown set, ignore
+		}
+
+	case *localnetv1.OpItem_Delete:
+		del := op.GetDelete()
+		path := globalsink.ParsePath(del.Path)
+
+		switch del.Set {
+		case localnetv1.Set_GlobalServiceInfos:
+			if l, ok := s.Interface.(ServicesListener); ok {
+				l.DeleteService(path.Namespace, path.Name)
+			}
+
+		case localnetv1.Set_GlobalEndpointInfos:
+			if l, ok := s.Interface.(EndpointsListener); ok && path.Name != "" {
+				l.DeleteEndpoint(path.Namespace, path.Name, path.Source+"/"+path.Key)
+			}
+
+		case localnetv1.Set_GlobalNodeInfos:
+			if l, ok := s.Interface.(NodesListener); ok {
+				l.DeleteNode(path.Name)
+			}
+
+		default:
+			// unknown set, ignore
+		}
+
+	case *localnetv1.OpItem_Sync:
+		s.Sync()
+	}
+
+	return
+}
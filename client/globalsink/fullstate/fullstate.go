@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fullstate gives the whole cluster-wide state to a callback on each sync, the global
+// counterpart of the localsink fullstate.
+//
+// Usage with a GlobalClient:
+//
+//	gc.Sink = decoder.New(fullstate.New(func(state *fullstate.State) { ... }))
+package fullstate
+
+import (
+	"sort"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/client/globalsink/decoder"
+)
+
+type ServiceEndpoints struct {
+	Service *localnetv1.ServiceInfo
+	// Endpoints of the service, ordered by key
+	Endpoints []*localnetv1.EndpointInfo
+}
+
+// State is the cluster-wide state, ordered by node name and by service namespace and name.
+type State struct {
+	Nodes    []*localnetv1.NodeInfo
+	Services []*ServiceEndpoints
+}
+
+type Callback func(state *State)
+type Setup func()
+
+// Sink keeps the state received from the global API and gives it to the Callback on each sync.
+type Sink struct {
+	Callback  Callback
+	SetupFunc Setup
+
+	nodes     map[string]*localnetv1.NodeInfo
+	services  map[string]*localnetv1.ServiceInfo
+	endpoints map[string]map[string]*localnetv1.EndpointInfo
+}
+
+var (
+	_ decoder.Interface         = &Sink{}
+	_ decoder.ServicesListener  = &Sink{}
+	_ decoder.EndpointsListener = &Sink{}
+	_ decoder.NodesListener     = &Sink{}
+)
+
+func New(callback Callback) *Sink {
+	s := &Sink{Callback: callback}
+	s.Reset()
+	return s
+}
+
+func (s *Sink) Setup() {
+	if s.SetupFunc != nil {
+		s.SetupFunc()
+	}
+}
+
+func (s *Sink) Reset() {
+	s.nodes = map[string]*localnetv1.NodeInfo{}
+	s.services = map[string]*localnetv1.ServiceInfo{}
+	s.endpoints = map[string]map[string]*localnetv1.EndpointInfo{}
+}
+
+func (s *Sink) SetService(info *localnetv1.ServiceInfo) {
+	svc := info.Service
+	s.services[svc.Namespace+"/"+svc.Name] = info
+}
+
+func (s *Sink) DeleteService(namespace, name string) {
+	delete(s.services, namespace+"/"+name)
+}
+
+func (s *Sink) SetEndpoint(namespace, serviceName, key string, info *localnetv1.EndpointInfo) {
+	svcKey := namespace + "/" + serviceName
+
+	eps := s.endpoints[svcKey]
+	if eps == nil {
+		eps = map[string]*localnetv1.EndpointInfo{}
+		s.endpoints[svcKey] = eps
+	}
+
+	eps[key] = info
+}
+
+func (s *Sink) DeleteEndpoint(namespace, serviceName, key string) {
+	svcKey := namespace + "/" + serviceName
+
+	eps := s.endpoints[svcKey]
+	delete(eps, key)
+
+	if len(eps) == 0 {
+		delete(s.endpoints, svcKey)
+	}
+}
+
+func (s *Sink) SetNode(info *localnetv1.NodeInfo) {
+	s.nodes[info.Node.Name] = info
+}
+
+func (s *Sink) DeleteNode(name string) {
+	delete(s.nodes, name)
+}
+
+// State returns the current state.
+func (s *Sink) State() (state *State) {
+	state = &State{
+		Nodes:    make([]*localnetv1.NodeInfo, 0, len(s.nodes)),
+		Services: make([]*ServiceEndpoints, 0, len(s.services)),
+	}
+
+	for _, name := range sortedKeys(s.nodes) {
+		state.Nodes = append(state.Nodes, s.nodes[name])
+	}
+
+	for _, svcKey := range sortedKeys(s.services) {
+		seps := &ServiceEndpoints{Service: s.services[svcKey]}
+
+		eps := s.endpoints[svcKey]
+		for _, key := range sortedKeys(eps) {
+			seps.Endpoints = append(seps.Endpoints, eps[key])
+		}
+
+		state.Services = append(state.Services, seps)
+	}
+
+	return
+}
+
+func (s *Sink) Sync() {
+	s.Callback(s.State())
+}
+
+func sortedKeys[V any](m map[string]V) (keys []string) {
+	keys = make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package globalsink defines the receivers of the global API's stream (see client.GlobalClient),
+// the cluster-wide counterpart of localsink.
+package globalsink
+
+import (
+	"strings"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+)
+
+type Sink interface {
+	// Setup is called once, when the client starts
+	Setup()
+
+	// Reset the state of the Sink (ie: when the client reconnects and can't resume from its last revision)
+	Reset()
+
+	localnetv1.OpSink
+}
+
+// Path is the path of a value in the global API's sets (namespace|name|source|key).
+type Path struct {
+	Namespace string
+	Name      string
+	Source    string
+	Key       string
+}
+
+// ParsePath parses a global API path; missing parts are left empty.
+func ParsePath(path string) (p Path) {
+	parts := strings.SplitN(path, "|", 4)
+	for len(parts) < 4 {
+		parts = append(parts, "")
+	}
+
+	return Path{parts[0], parts[1], parts[2], parts[3]}
+}
@@ -83,9 +83,12 @@ func (s *DiffStore) Set(key []byte, hash uint64, value interface{}) {
 	v.state = ItemChanged
 }
 
-// Delete an entry from the store
+// Delete an entry from the store (no-op if the entry doesn't exist)
 func (s *DiffStore) Delete(key []byte) {
 	item := s.tree.Get(&storeKV{key: key})
+	if item == nil {
+		return
+	}
 	item.(*storeKV).state = ItemDeleted
 }
 
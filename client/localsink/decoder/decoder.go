@@ -33,12 +33,22 @@ type ServicesListener interface {
 }
 
 type EndpointsListener interface {
-	// SetEndpoint is called when an endpoint is added or updated
+	// SetEndpoint is called when an endpoint is added or updated.
+	// The endpoint is flagged with its eligibility for internal and/or external traffic (see InternalOnly to only receive the internal ones).
 	SetEndpoint(namespace, serviceName, key string, endpoint *localnetv1.Endpoint)
 	// DeleteEndpoint is called when an endpoint is deleted
 	DeleteEndpoint(namespace, serviceName, key string)
 }
 
+// NodeListener can be implemented by an Interface to receive the record of its node
+// (addresses, pod CIDRs, etc).
+type NodeListener interface {
+	// SetNode is called when the node is added or updated
+	SetNode(node *localnetv1.Node)
+	// DeleteNode is called when the node is deleted
+	DeleteNode(name string)
+}
+
 type Interface interface {
 	// Sync signals an stream sync event
 	Sync()
@@ -61,14 +71,36 @@ type Interface interface {
 	Reset()
 }
 
+// Sink decodes the local API operations for an Interface.
+//
+// Headless services and their endpoints are not sent to the Interface, as
+// they have no IP to proxy. A service becoming headless is deleted, with its
+// endpoints already sent.
 type Sink struct {
 	Interface
+
+	// headless services (namespace/name)
+	headless map[string]bool
+
+	// keys of the endpoints sent, by service (namespace/name)
+	endpoints map[string]map[string]bool
 }
 
 var _ localsink.Sink = &Sink{}
 
 func New(iface Interface) *Sink {
-	return &Sink{iface}
+	return &Sink{Interface: iface}
+}
+
+func (s *Sink) Reset() {
+	s.headless = nil
+	s.endpoints = nil
+	s.Interface.Reset()
+}
+
+// ApplyStatus forwards the status of the Interface if it's a localsink.StatusReporter.
+func (s *Sink) ApplyStatus() *localnetv1.ApplyStatus {
+	return localsink.ApplyStatusOf(s.Interface)
 }
 
 func (s *Sink) Send(op *localnetv1.OpItem) (err error) {
@@ -85,6 +117,27 @@ func (s *Sink) Send(op *localnetv1.OpItem) (err error) {
 				return
 			}
 
+			key := v.Namespace + "/" + v.Name
+			if v.IPs.GetHeadless() {
+				if s.headless == nil {
+					s.headless = map[string]bool{}
+				}
+				s.headless[key] = true
+
+				if keys, ok := s.endpoints[key]; ok {
+					// the service was sent before becoming headless
+					for epKey := range keys {
+						s.DeleteEndpoint(v.Namespace, v.Name, epKey)
+					}
+					delete(s.endpoints, key)
+					s.DeleteService(v.Namespace, v.Name)
+				}
+				return
+			}
+			delete(s.headless, key)
+
+			s.sentEndpoints(key) // record the service as sent
+
 			s.SetService(v)
 
 		case localnetv1.Set_EndpointsSet:
@@ -96,8 +149,30 @@ func (s *Sink) Send(op *localnetv1.OpItem) (err error) {
 			}
 
 			parts := strings.Split(set.Ref.Path, "/")
+			key := parts[0] + "/" + parts[1]
+			if s.headless[key] {
+				return
+			}
+
+			s.sentEndpoints(key)[parts[2]] = true
+
 			s.SetEndpoint(parts[0], parts[1], parts[2], v)
 
+		case localnetv1.Set_NodesSet:
+			l, ok := s.Interface.(NodeListener)
+			if !ok {
+				return
+			}
+
+			v := &localnetv1.Node{}
+
+			err = proto.Unmarshal(set.Bytes, v)
+			if err != nil {
+				return
+			}
+
+			l.SetNode(v)
+
 		default:
 			return
 		}
@@ -108,11 +183,30 @@ func (s *Sink) Send(op *localnetv1.OpItem) (err error) {
 
 		switch del.Set {
 		case localnetv1.Set_ServicesSet: // Service: namespace/name
+			if s.headless[del.Path] {
+				delete(s.headless, del.Path)
+				return
+			}
+
+			delete(s.endpoints, del.Path)
+
 			s.DeleteService(parts[0], parts[1])
 
 		case localnetv1.Set_EndpointsSet: // Endpoint: namespace/name/key
+			key := parts[0] + "/" + parts[1]
+			if s.headless[key] {
+				return
+			}
+
+			delete(s.endpoints[key], parts[2])
+
 			s.DeleteEndpoint(parts[0], parts[1], parts[2])
 
+		case localnetv1.Set_NodesSet: // Node: name
+			if l, ok := s.Interface.(NodeListener); ok {
+				l.DeleteNode(del.Path)
+			}
+
 		default:
 			// unknown set, ignore
 		}
@@ -123,3 +217,17 @@ func (s *Sink) Send(op *localnetv1.OpItem) (err error) {
 
 	return
 }
+
+// sentEndpoints returns the keys of the endpoints sent for the service, recording the service as sent.
+func (s *Sink) sentEndpoints(service string) map[string]bool {
+	if s.endpoints == nil {
+		s.endpoints = map[string]map[string]bool{}
+	}
+
+	keys, ok := s.endpoints[service]
+	if !ok {
+		keys = map[string]bool{}
+		s.endpoints[service] = keys
+	}
+	return keys
+}
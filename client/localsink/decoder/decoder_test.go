@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decoder
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+)
+
+func ExampleSink_headless() {
+	s := New(printBackend{})
+
+	set := func(set localnetv1.Set, path string, v proto.Message) {
+		ba, _ := proto.Marshal(v)
+		s.Send(&localnetv1.OpItem{Op: &localnetv1.OpItem_Set{Set: &localnetv1.Value{
+			Ref:   &localnetv1.Ref{Set: set, Path: path},
+			Bytes: ba,
+		}}})
+	}
+	del := func(set localnetv1.Set, path string) {
+		s.Send(&localnetv1.OpItem{Op: &localnetv1.OpItem_Delete{Delete: &localnetv1.Ref{Set: set, Path: path}}})
+	}
+
+	set(localnetv1.Set_ServicesSet, "ns/web", &localnetv1.Service{Namespace: "ns", Name: "web",
+		IPs: &localnetv1.ServiceIPs{ClusterIPs: localnetv1.NewIPSet("10.1.2.3")}})
+	set(localnetv1.Set_EndpointsSet, "ns/web/a", &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.2.0.1")})
+
+	set(localnetv1.Set_ServicesSet, "ns/db", &localnetv1.Service{Namespace: "ns", Name: "db",
+		IPs: &localnetv1.ServiceIPs{Headless: true}})
+	set(localnetv1.Set_EndpointsSet, "ns/db/b", &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.2.0.2")})
+	del(localnetv1.Set_EndpointsSet, "ns/db/b")
+	del(localnetv1.Set_ServicesSet, "ns/db")
+
+	del(localnetv1.Set_EndpointsSet, "ns/web/a")
+
+	// a service becoming headless is deleted with its endpoints
+	set(localnetv1.Set_EndpointsSet, "ns/web/c", &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.2.0.3")})
+	set(localnetv1.Set_ServicesSet, "ns/web", &localnetv1.Service{Namespace: "ns", Name: "web",
+		IPs: &localnetv1.ServiceIPs{Headless: true}})
+	set(localnetv1.Set_EndpointsSet, "ns/web/d", &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.2.0.4")})
+
+	// Output:
+	// SetService web
+	// SetEndpoint a [10.2.0.1]
+	// DeleteEndpoint a
+	// SetEndpoint c [10.2.0.3]
+	// DeleteEndpoint c
+	// DeleteService web
+}
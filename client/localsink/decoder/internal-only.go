@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decoder
+
+import (
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/client/localsink"
+)
+
+type internalOnly struct {
+	Interface
+
+	// sent endpoints (namespace/serviceName/key)
+	sent map[string]bool
+}
+
+// InternalOnly wraps a decoder so it only receives the endpoints eligible for
+// internal traffic, as expected by backends not handling external traffic
+// separately. An endpoint losing its eligibility is seen as deleted.
+func InternalOnly(backend Interface) Interface {
+	return &internalOnly{
+		Interface: backend,
+		sent:      map[string]bool{},
+	}
+}
+
+func (w *internalOnly) Reset() {
+	w.sent = map[string]bool{}
+	w.Interface.Reset()
+}
+
+func (w *internalOnly) SetEndpoint(namespace, serviceName, key string, endpoint *localnetv1.Endpoint) {
+	epKey := namespace + "/" + serviceName + "/" + key
+
+	if endpoint.IsInternal() {
+		w.sent[epKey] = true
+		w.Interface.SetEndpoint(namespace, serviceName, key, endpoint)
+		return
+	}
+
+	w.DeleteEndpoint(namespace, serviceName, key)
+}
+
+func (w *internalOnly) DeleteEndpoint(namespace, serviceName, key string) {
+	epKey := namespace + "/" + serviceName + "/" + key
+
+	if !w.sent[epKey] {
+		return // never sent to the backend
+	}
+
+	delete(w.sent, epKey)
+	w.Interface.DeleteEndpoint(namespace, serviceName, key)
+}
+
+func (w *internalOnly) SetNode(node *localnetv1.Node) {
+	if l, ok := w.Interface.(NodeListener); ok {
+		l.SetNode(node)
+	}
+}
+
+func (w *internalOnly) DeleteNode(name string) {
+	if l, ok := w.Interface.(NodeListener); ok {
+		l.DeleteNode(name)
+	}
+}
+
+func (w *internalOnly) ApplyStatus() *localnetv1.ApplyStatus {
+	return localsink.ApplyStatusOf(w.Interface)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package decoder
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+)
+
+type printBackend struct{}
+
+func (_ printBackend) Sync()  {}
+func (_ printBackend) Setup() {}
+func (_ printBackend) Reset() { fmt.Println("Reset") }
+func (_ printBackend) SetService(service *localnetv1.Service) {
+	fmt.Println("SetService", service.Name)
+}
+func (_ printBackend) DeleteService(namespace, name string) {
+	fmt.Println("DeleteService", name)
+}
+func (_ printBackend) WaitRequest() (nodeName string, err error) {
+	return "localhost", nil
+}
+func (_ printBackend) SetEndpoint(namespace, serviceName, key string, endpoint *localnetv1.Endpoint) {
+	fmt.Println("SetEndpoint", key, endpoint.IPs.V4)
+}
+func (_ printBackend) DeleteEndpoint(namespace, serviceName, key string) {
+	fmt.Println("DeleteEndpoint", key)
+}
+
+func ExampleInternalOnly() {
+	w := InternalOnly(printBackend{})
+
+	w.SetEndpoint("ns", "svc", "a", &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.1.0.1"), NotExternal: true})
+	w.SetEndpoint("ns", "svc", "b", &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.1.0.2"), NotInternal: true})
+	w.SetEndpoint("ns", "svc", "a", &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.1.0.1"), NotInternal: true})
+	w.DeleteEndpoint("ns", "svc", "a")
+	w.DeleteEndpoint("ns", "svc", "b")
+
+	// endpoints from servers not setting the eligibility flags are eligible for both
+	w.SetEndpoint("ns", "svc", "c", &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.1.0.3")})
+
+	// Output:
+	// SetEndpoint a [10.1.0.1]
+	// DeleteEndpoint a
+	// SetEndpoint c [10.1.0.3]
+}
@@ -50,6 +50,11 @@ func (s *Sink) WaitRequest() (nodeName string, err error) {
 	return s.sink.WaitRequest()
 }
 
+// ApplyStatus forwards the status of the wrapped sink if it's a localsink.StatusReporter.
+func (s *Sink) ApplyStatus() *localnetv1.ApplyStatus {
+	return localsink.ApplyStatusOf(s.sink)
+}
+
 func (s *Sink) Reset() {
 	s.filtering = true
 	s.seen = make(map[string]bool, len(s.memory))
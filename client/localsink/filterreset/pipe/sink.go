@@ -54,3 +54,18 @@ func (ps *Sink) Send(op *localnetv1.OpItem) error {
 	}
 	return nil
 }
+
+// ApplyStatus merges the statuses of the target sinks implementing localsink.StatusReporter; the first error wins.
+func (ps *Sink) ApplyStatus() (status *localnetv1.ApplyStatus) {
+	for _, sink := range ps.targetSinks {
+		s := localsink.ApplyStatusOf(sink)
+		if s == nil {
+			continue
+		}
+
+		if status == nil || (status.Error == "" && s.Error != "") {
+			status = s
+		}
+	}
+	return
+}
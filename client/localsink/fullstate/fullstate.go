@@ -17,6 +17,9 @@ limitations under the License.
 package fullstate
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/google/btree"
 	"google.golang.org/protobuf/proto"
 
@@ -29,7 +32,53 @@ type ServiceEndpoints struct {
 	Endpoints []*localnetv1.Endpoint
 }
 
+// InternalEndpoints returns the endpoints eligible for internal traffic (to cluster IPs)
+func (seps *ServiceEndpoints) InternalEndpoints() []*localnetv1.Endpoint {
+	return seps.filterEndpoints(func(ep *localnetv1.Endpoint) bool { return ep.IsInternal() })
+}
+
+// ExternalEndpoints returns the endpoints eligible for external traffic (to node ports, external IPs and load-balancer IPs)
+func (seps *ServiceEndpoints) ExternalEndpoints() []*localnetv1.Endpoint {
+	return seps.filterEndpoints(func(ep *localnetv1.Endpoint) bool { return ep.IsExternal() })
+}
+
+func (seps *ServiceEndpoints) filterEndpoints(filter func(ep *localnetv1.Endpoint) bool) (endpoints []*localnetv1.Endpoint) {
+	endpoints = make([]*localnetv1.Endpoint, 0, len(seps.Endpoints))
+	for _, ep := range seps.Endpoints {
+		if filter(ep) {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return
+}
+
+func (seps *ServiceEndpoints) equal(other *ServiceEndpoints) bool {
+	if !proto.Equal(seps.Service, other.Service) || len(seps.Endpoints) != len(other.Endpoints) {
+		return false
+	}
+	for i, ep := range seps.Endpoints {
+		if !proto.Equal(ep, other.Endpoints[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Changes are the services changed since the previous sync, ordered by namespace and name.
+type Changes struct {
+	Added   []*ServiceEndpoints
+	Updated []*ServiceEndpoints
+	// Removed services are given as they were at the previous sync
+	Removed []*ServiceEndpoints
+}
+
+// Empty returns true if no service changed.
+func (c *Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Updated) == 0 && len(c.Removed) == 0
+}
+
 type Callback func(item <-chan *ServiceEndpoints)
+type ChangesCallback func(changes *Changes)
 type Setup func()
 
 // EndpointsClient is a simple client to kube-proxy's Endpoints API.
@@ -38,7 +87,18 @@ type Sink struct {
 	Callback  Callback
 	SetupFunc Setup
 
+	// ChangesCallback, if set, receives the services changed since the previous sync (after the Callback if any)
+	ChangesCallback ChangesCallback
+
+	// Status can be set by the callback with the result of the apply, to be reported to the server
+	localsink.Status
+
 	data *btree.BTree
+	node *localnetv1.Node
+
+	// changed services since the last sync, and their state at the last sync (only tracked for the ChangesCallback)
+	changed  map[string]bool
+	previous map[string]*ServiceEndpoints
 }
 
 func New(config *localsink.Config) *Sink {
@@ -79,6 +139,17 @@ func (s *Sink) WaitRequest() (nodeName string, err error) {
 
 func (s *Sink) Reset() {
 	s.data.Clear(false)
+	s.node = nil
+
+	// services not sent again after the reset are removed
+	for key := range s.previous {
+		s.markChanged(key)
+	}
+}
+
+// Node returns the last known record of the node (nil if unknown).
+func (s *Sink) Node() *localnetv1.Node {
+	return s.node
 }
 
 func (s *Sink) Send(op *localnetv1.OpItem) (err error) {
@@ -92,6 +163,13 @@ func (s *Sink) Send(op *localnetv1.OpItem) (err error) {
 			v = &localnetv1.Service{}
 		case localnetv1.Set_EndpointsSet:
 			v = &localnetv1.Endpoint{}
+		case localnetv1.Set_NodesSet:
+			node := &localnetv1.Node{}
+			err = proto.Unmarshal(set.Bytes, node)
+			if err == nil {
+				s.node = node
+			}
+			return
 
 		default:
 			return
@@ -103,40 +181,143 @@ func (s *Sink) Send(op *localnetv1.OpItem) (err error) {
 		}
 
 		s.data.ReplaceOrInsert(kv{set.Ref.Path, v})
+		s.markChanged(set.Ref.Path)
 
 	case *localnetv1.OpItem_Delete:
+		if op.GetDelete().Set == localnetv1.Set_NodesSet {
+			s.node = nil
+			return
+		}
+
 		s.data.Delete(kv{Path: op.GetDelete().Path})
+		s.markChanged(op.GetDelete().Path)
 
 	case *localnetv1.OpItem_Sync:
-		results := make(chan *ServiceEndpoints, 1)
+		if s.Callback != nil {
+			s.callback()
+		}
 
-		go func() {
-			defer close(results)
+		if s.ChangesCallback != nil {
+			s.ChangesCallback(s.changes())
+		}
+	}
 
-			var seps *ServiceEndpoints
+	return
+}
 
-			s.data.Ascend(func(i btree.Item) bool {
-				switch v := i.(kv).Value.(type) {
-				case *localnetv1.Service:
-					if seps != nil {
-						results <- seps
-					}
+func (s *Sink) callback() {
+	results := make(chan *ServiceEndpoints, 1)
 
-					seps = &ServiceEndpoints{Service: v}
-				case *localnetv1.Endpoint:
-					seps.Endpoints = append(seps.Endpoints, v)
-				}
+	go func() {
+		defer close(results)
+
+		var seps *ServiceEndpoints
 
-				return true
-			})
+		s.data.Ascend(func(i btree.Item) bool {
+			switch v := i.(kv).Value.(type) {
+			case *localnetv1.Service:
+				if seps != nil {
+					results <- seps
+				}
 
-			if seps != nil {
-				results <- seps
+				seps = &ServiceEndpoints{Service: v}
+			case *localnetv1.Endpoint:
+				seps.Endpoints = append(seps.Endpoints, v)
 			}
-		}()
 
-		s.Callback(results)
+			return true
+		})
+
+		if seps != nil {
+			results <- seps
+		}
+	}()
+
+	s.Callback(results)
+}
+
+// markChanged records a change of the service of the given service or endpoint path.
+func (s *Sink) markChanged(path string) {
+	if s.ChangesCallback == nil {
+		return
+	}
+
+	if s.changed == nil {
+		s.changed = map[string]bool{}
+	}
+
+	// paths are namespace/name for services, and namespace/name/key for endpoints
+	if parts := strings.SplitN(path, "/", 3); len(parts) >= 2 {
+		path = parts[0] + "/" + parts[1]
+	}
+
+	s.changed[path] = true
+}
+
+// changes returns the changes of the services since the previous call.
+func (s *Sink) changes() (changes *Changes) {
+	changes = &Changes{}
+
+	if s.previous == nil {
+		s.previous = map[string]*ServiceEndpoints{}
+	}
+
+	keys := make([]string, 0, len(s.changed))
+	for key := range s.changed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		prev := s.previous[key]
+		seps := s.serviceEndpoints(key)
+
+		switch {
+		case prev == nil && seps == nil:
+			// added and removed since the previous sync
+
+		case prev == nil:
+			changes.Added = append(changes.Added, seps)
+			s.previous[key] = seps
+
+		case seps == nil:
+			changes.Removed = append(changes.Removed, prev)
+			delete(s.previous, key)
+
+		default:
+			if !seps.equal(prev) {
+				changes.Updated = append(changes.Updated, seps)
+			}
+			s.previous[key] = seps
+		}
 	}
 
+	s.changed = nil
+
+	return
+}
+
+// serviceEndpoints returns the current state of a service (nil if unknown).
+func (s *Sink) serviceEndpoints(key string) (seps *ServiceEndpoints) {
+	s.data.AscendGreaterOrEqual(kv{Path: key}, func(i btree.Item) bool {
+		item := i.(kv)
+
+		switch v := item.Value.(type) {
+		case *localnetv1.Service:
+			if item.Path != key {
+				return false
+			}
+			seps = &ServiceEndpoints{Service: v}
+
+		case *localnetv1.Endpoint:
+			if seps == nil || !strings.HasPrefix(item.Path, key+"/") {
+				return false
+			}
+			seps.Endpoints = append(seps.Endpoints, v)
+		}
+
+		return true
+	})
+
 	return
 }
@@ -72,3 +72,59 @@ func TestAddRemoveService(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestChangesCallback(t *testing.T) {
+	var changes *Changes
+
+	sink := New(nil)
+	sink.ChangesCallback = func(c *Changes) { changes = c }
+
+	set := func(set localnetv1.Set, path string, v proto.Message) {
+		b, _ := proto.Marshal(v)
+		sink.Send(&localnetv1.OpItem{Op: &localnetv1.OpItem_Set{Set: &localnetv1.Value{
+			Ref:   &localnetv1.Ref{Set: set, Path: path},
+			Bytes: b,
+		}}})
+	}
+	del := func(set localnetv1.Set, path string) {
+		sink.Send(&localnetv1.OpItem{Op: &localnetv1.OpItem_Delete{Delete: &localnetv1.Ref{Set: set, Path: path}}})
+	}
+
+	check := func(step string, added, updated, removed int) {
+		t.Helper()
+		if changes == nil {
+			t.Fatalf("%s: no changes callback", step)
+		}
+		if len(changes.Added) != added || len(changes.Updated) != updated || len(changes.Removed) != removed {
+			t.Errorf("%s: expected %d/%d/%d added/updated/removed, got %d/%d/%d", step, added, updated, removed,
+				len(changes.Added), len(changes.Updated), len(changes.Removed))
+		}
+		changes = nil
+	}
+
+	set(localnetv1.Set_ServicesSet, "test/a", &localnetv1.Service{Namespace: "test", Name: "a"})
+	set(localnetv1.Set_ServicesSet, "test/b", &localnetv1.Service{Namespace: "test", Name: "b"})
+	sink.Send(syncOp)
+	check("add", 2, 0, 0)
+
+	set(localnetv1.Set_EndpointsSet, "test/a/ep1", &localnetv1.Endpoint{Hostname: "ep1"})
+	sink.Send(syncOp)
+	check("add endpoint", 0, 1, 0)
+	if eps := len(sink.previous["test/a"].Endpoints); eps != 1 {
+		t.Errorf("expected 1 endpoint, got %d", eps)
+	}
+
+	set(localnetv1.Set_ServicesSet, "test/b", &localnetv1.Service{Namespace: "test", Name: "b"})
+	sink.Send(syncOp)
+	check("unchanged set", 0, 0, 0)
+
+	del(localnetv1.Set_EndpointsSet, "test/a/ep1")
+	del(localnetv1.Set_ServicesSet, "test/a")
+	sink.Send(syncOp)
+	check("delete", 0, 0, 1)
+
+	sink.Reset()
+	set(localnetv1.Set_ServicesSet, "test/c", &localnetv1.Service{Namespace: "test", Name: "c"})
+	sink.Send(syncOp)
+	check("reset", 1, 0, 1)
+}
@@ -18,7 +18,8 @@ package fullstatepipe
 
 import (
 	"fmt"
-	"sync"
+
+	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kpng/client"
 	"sigs.k8s.io/kpng/client/localsink/fullstate"
@@ -27,119 +28,171 @@ import (
 type Strategy int
 
 const (
-	// Sequence calls to each pipe stage in sequence. Implies storing the state in a buffer.
+	// Sequence calls to each pipe stage in sequence, from the buffered state.
 	Sequence = iota
-	// Parallel calls each pipe stage in parallel. No buffering required, but
+	// Parallel calls each pipe stage in parallel, as the state is read, but
 	// the stages are not really stages anymore.
 	Parallel
 	// ParallelSendSequenceClose calls each pipe entry in parallel but closes
-	// the channel of a stage only after the previous has finished. No
-	// buffering required but still a meaningful sequencing, especially when
-	// using the diffstore.
+	// the channel of a stage only after the previous has finished. Still a
+	// meaningful sequencing, especially when using the diffstore.
 	ParallelSendSequenceClose
 )
 
+// Pipe gives the state to its stages according to its strategy. The state is always buffered, for
+// retries and for the stages requiring other stages (see Stage.Requires): they receive the state
+// once their requirements have finished, whatever the strategy.
 type Pipe struct {
 	strategy Strategy
-	stages   []fullstate.Callback
+	stages   []*Stage
 	buffer   []*client.ServiceEndpoints
 }
 
+// New creates a pipe of stages that can't fail (they're still protected from panics).
 func New(strategy Strategy, stages ...fullstate.Callback) *Pipe {
-	return &Pipe{
-		strategy: strategy,
-		stages:   stages,
+	pipeStages := make([]Stage, len(stages))
+	for idx, stage := range stages {
+		pipeStages[idx] = Stage{
+			Name: fmt.Sprint("stage-", idx+1),
+			Func: WithoutError(stage),
+		}
 	}
+
+	return NewStages(strategy, pipeStages...)
 }
 
-func (pipe *Pipe) Callback(ch <-chan *client.ServiceEndpoints) {
-	switch pipe.strategy {
-	case Sequence:
-		if pipe.buffer == nil {
-			pipe.buffer = make([]*client.ServiceEndpoints, 0)
-		}
+// NewStages creates a pipe of stages with their error handling options.
+func NewStages(strategy Strategy, stages ...Stage) *Pipe {
+	pipe := &Pipe{strategy: strategy}
 
-		buf := pipe.buffer
+	for idx := range stages {
+		stage := stages[idx]
 
-		for item := range ch {
-			buf = append(buf, item)
+		for _, required := range stage.Requires {
+			found := false
+			for _, prev := range pipe.stages {
+				found = found || prev.Name == required
+			}
+			if !found {
+				panic(fmt.Errorf("stage %s requires %q, which is not an earlier stage", stage.Name, required))
+			}
 		}
 
-		for _, stage := range pipe.stages {
-			myCh := make(chan *client.ServiceEndpoints, 1)
-			go func() {
-				for _, item := range buf {
-					myCh <- item
-				}
-				close(myCh)
-			}()
+		pipe.stages = append(pipe.stages, &stage)
+	}
 
-			stage(myCh)
-		}
+	return pipe
+}
 
-		pipe.buffer = buf[:0]
+// Callback runs the pipe, logging the stages' failures.
+func (pipe *Pipe) Callback(ch <-chan *client.ServiceEndpoints) {
+	if err := pipe.Run(ch); err != nil {
+		klog.Error("pipe failed: ", err)
+	}
+}
 
-	case Parallel:
-		channels := make([]chan *client.ServiceEndpoints, len(pipe.stages))
+// Run gives the state to the stages, returning the stages' failures as Errors (nil if all succeeded).
+func (pipe *Pipe) Run(ch <-chan *client.ServiceEndpoints) error {
+	if pipe.buffer == nil {
+		pipe.buffer = make([]*client.ServiceEndpoints, 0)
+	}
+
+	buf := pipe.buffer
 
-		wg := new(sync.WaitGroup)
-		wg.Add(len(pipe.stages))
+	// input is closed when the whole state is in buf
+	input := make(chan struct{})
 
+	runs := make([]*stageRun, len(pipe.stages))
+
+	if pipe.strategy != Sequence {
+		// stages without requirements receive the state as it's read
 		for idx, stage := range pipe.stages {
-			childCh := make(chan *client.ServiceEndpoints, 2)
-			channels[idx] = childCh
-
-			stage := stage
-			go func() {
-				defer wg.Done()
-				stage(childCh)
-			}()
+			if len(stage.Requires) == 0 {
+				runs[idx] = stage.start(input, &buf)
+			}
 		}
+	}
 
-		for item := range ch {
-			for _, childCh := range channels {
-				childCh <- item
+	for item := range ch {
+		buf = append(buf, item)
+
+		for _, run := range runs {
+			if run != nil {
+				run.send(item)
 			}
 		}
+	}
+
+	close(input)
 
-		for _, childCh := range channels {
-			close(childCh)
+	switch pipe.strategy {
+	case Sequence, ParallelSendSequenceClose:
+		// nothing to do, stages are closed in sequence
+
+	case Parallel:
+		for _, run := range runs {
+			if run != nil {
+				run.close()
+			}
 		}
 
-		wg.Wait()
+	default:
+		panic(fmt.Errorf("unknown strategy: %d", pipe.strategy))
+	}
 
-	case ParallelSendSequenceClose:
-		channels := make([]chan *client.ServiceEndpoints, len(pipe.stages))
-		waitGroups := make([]*sync.WaitGroup, len(pipe.stages))
+	var errs Errors
+	timedOut := false
 
-		for idx, stage := range pipe.stages {
-			childCh := make(chan *client.ServiceEndpoints, 2)
-			channels[idx] = childCh
+	for idx, stage := range pipe.stages {
+		run := runs[idx]
 
-			wg := new(sync.WaitGroup)
-			waitGroups[idx] = wg
+		if run == nil {
+			if failed := pipe.failedRequirement(stage, runs); failed != "" {
+				klog.V(1).Infof("stage %s skipped: required stage %s failed", stage.Name, failed)
+				stageFailures.WithLabelValues(stage.Name, "skipped").Inc()
+				continue
+			}
 
-			stage := stage
+			run = stage.start(input, &buf)
+			runs[idx] = run
 
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				stage(childCh)
-			}()
-		}
+			go run.feed(buf)
 
-		for item := range ch {
-			for _, childCh := range channels {
-				childCh <- item
-			}
+		} else {
+			run.close()
 		}
 
-		for idx, childCh := range channels {
-			close(childCh)
-			waitGroups[idx].Wait()
+		if err := run.wait(); err != nil {
+			errs = append(errs, &StageError{Stage: stage.Name, Err: err})
+			timedOut = timedOut || err == ErrTimeout
 		}
+	}
 
-	default:
-		panic(fmt.Errorf("unknown strategy: %d", pipe.strategy))
+	if timedOut {
+		// the stages still running may use the buffer
+		pipe.buffer = nil
+	} else {
+		pipe.buffer = buf[:0]
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// failedRequirement returns the name of a stage required by the given one that didn't succeed.
+func (pipe *Pipe) failedRequirement(stage *Stage, runs []*stageRun) string {
+	for _, required := range stage.Requires {
+		for idx, prev := range pipe.stages {
+			if prev.Name != required {
+				continue
+			}
+
+			if runs[idx] == nil || runs[idx].wait() != nil {
+				return required
+			}
+		}
 	}
+	return ""
 }
@@ -18,6 +18,7 @@ package fullstatepipe
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -95,6 +96,47 @@ func ExampleParallelSendSequenceClose() {
 	// s3 finished
 }
 
+func ExampleNewStages() {
+	go failAfter1Sec()
+
+	panicked := false
+
+	pipe := NewStages(ParallelSendSequenceClose,
+		Stage{Name: "apply", Func: func(ch <-chan *client.ServiceEndpoints) error {
+			for range ch {
+			}
+			return errors.New("apply failed")
+		}},
+		Stage{Name: "cleanup", Requires: []string{"apply"}, Func: WithoutError(delayCallback("cleanup", 0))},
+		Stage{Name: "flaky", OnPanic: PanicRetry, Func: func(ch <-chan *client.ServiceEndpoints) error {
+			if !panicked {
+				panicked = true
+				panic("oops")
+			}
+			return WithoutError(delayCallback("flaky", 0))(ch)
+		}},
+		Stage{Name: "stuck", Timeout: 10 * time.Millisecond, Func: func(ch <-chan *client.ServiceEndpoints) error {
+			time.Sleep(100 * time.Millisecond)
+			return nil
+		}},
+	)
+
+	err := pipe.Run(singleServiceCh("my-service"))
+
+	out.print()
+	fmt.Println(err)
+
+	// the stuck stage is still running
+	fmt.Println(pipe.Run(singleServiceCh("my-service")))
+	out.Reset()
+
+	// Output:
+	// flaky got service my-service
+	// flaky finished
+	// apply: apply failed; stuck: timed out
+	// apply: apply failed; stuck: previous run still running
+}
+
 func failAfter1Sec() {
 	time.Sleep(time.Second)
 	panic("example timed out")
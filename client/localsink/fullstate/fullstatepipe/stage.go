@@ -0,0 +1,305 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fullstatepipe
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kpng/client"
+	"sigs.k8s.io/kpng/client/localsink/fullstate"
+)
+
+// StageFunc is a pipe stage able to report its failure.
+type StageFunc func(ch <-chan *client.ServiceEndpoints) error
+
+// WithoutError wraps a callback that can't fail.
+func WithoutError(callback fullstate.Callback) StageFunc {
+	return func(ch <-chan *client.ServiceEndpoints) error {
+		callback(ch)
+		return nil
+	}
+}
+
+type PanicPolicy int
+
+const (
+	// PanicSkip recovers the panics of a stage, considering it failed.
+	PanicSkip PanicPolicy = iota
+	// PanicRetry recovers the panics of a stage and runs it again with the same state, up to Retries times.
+	PanicRetry
+)
+
+// Stage is a pipe stage with its isolation options.
+type Stage struct {
+	// Name of the stage, as used in logs, metrics and Requires
+	Name string
+	Func StageFunc
+
+	// Timeout of the stage (none if 0). A stage timing out is considered failed and isn't waited
+	// for; it can't run again until its previous run returns.
+	Timeout time.Duration
+
+	OnPanic PanicPolicy
+	// Retries is the maximum number of retries with the PanicRetry policy (1 if 0)
+	Retries int
+
+	// Requires are the names of earlier stages that must succeed for this stage to run. Such a stage
+	// receives the state once the required stages have finished.
+	Requires []string
+
+	running int32
+}
+
+var (
+	// ErrTimeout is the error of a stage not finished within its timeout.
+	ErrTimeout = errors.New("timed out")
+	// ErrStillRunning is the error of a stage whose previous run (which timed out) is still running.
+	ErrStillRunning = errors.New("previous run still running")
+)
+
+// PanicError is the error of a stage that panicked.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprint("panic: ", e.Value)
+}
+
+// StageError is the failure of a stage.
+type StageError struct {
+	Stage string
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return e.Stage + ": " + e.Err.Error()
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
+
+// Errors are the failures of the stages of a pipe run, in the stages order.
+type Errors []*StageError
+
+func (errs Errors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+var (
+	stageDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kpng",
+		Subsystem: "fullstatepipe",
+		Name:      "stage_duration_seconds",
+		Help:      "Duration of the pipe stages' runs, including the time to receive the state",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+	}, []string{"stage"})
+
+	stageFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kpng",
+		Subsystem: "fullstatepipe",
+		Name:      "stage_failures_total",
+		Help:      "Number of failed or skipped runs of the pipe stages, by reason (error, panic, timeout, running, skipped)",
+	}, []string{"stage", "reason"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		stageDuration,
+		stageFailures,
+	)
+}
+
+func failureReason(err error) string {
+	var panicErr *PanicError
+	switch {
+	case errors.As(err, &panicErr):
+		return "panic"
+	case errors.Is(err, ErrTimeout):
+		return "timeout"
+	case errors.Is(err, ErrStillRunning):
+		return "running"
+	default:
+		return "error"
+	}
+}
+
+// stageRun is a run of a stage
+type stageRun struct {
+	stage *Stage
+	start time.Time
+
+	// ch receives the state
+	ch chan *client.ServiceEndpoints
+	// done is closed when the stage returns or times out
+	done chan struct{}
+	once sync.Once
+	err  error
+
+	closed bool
+}
+
+// start runs the stage in background, receiving the state from run.ch. The buffer must hold the
+// whole state once input is closed; it's used for retries.
+func (stage *Stage) start(input <-chan struct{}, buffer *[]*client.ServiceEndpoints) (run *stageRun) {
+	run = &stageRun{
+		stage: stage,
+		start: time.Now(),
+		ch:    make(chan *client.ServiceEndpoints, 2),
+		done:  make(chan struct{}),
+	}
+
+	if !atomic.CompareAndSwapInt32(&stage.running, 0, 1) {
+		run.finish(ErrStillRunning)
+		return
+	}
+
+	ch := run.ch
+
+	var timer *time.Timer
+	if stage.Timeout > 0 {
+		timer = time.AfterFunc(stage.Timeout, func() { run.finish(ErrTimeout) })
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&stage.running, 0)
+
+		if timer != nil {
+			defer timer.Stop()
+		}
+
+		err := stage.call(ch)
+
+		retries := stage.Retries
+		if retries <= 0 {
+			retries = 1
+		}
+
+		for attempt := 1; attempt <= retries && stage.OnPanic == PanicRetry; attempt++ {
+			var panicErr *PanicError
+			if !errors.As(err, &panicErr) {
+				break
+			}
+
+			klog.Warningf("stage %s: %v, retrying (%d/%d)", stage.Name, err, attempt, retries)
+
+			<-input // the whole state is buffered
+			err = stage.call(replay(*buffer, run.done))
+		}
+
+		run.finish(err)
+	}()
+
+	return
+}
+
+// call calls the stage function, recovering panics. The state channel is drained when it returns.
+func (stage *Stage) call(ch <-chan *client.ServiceEndpoints) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+
+		go func() {
+			for range ch {
+			}
+		}()
+	}()
+
+	return stage.Func(ch)
+}
+
+// send sends an item to the stage, unless it's done.
+func (run *stageRun) send(item *client.ServiceEndpoints) {
+	select {
+	case run.ch <- item:
+	case <-run.done:
+	}
+}
+
+func (run *stageRun) finish(err error) {
+	run.once.Do(func() {
+		run.err = err
+		close(run.done)
+
+		stageDuration.WithLabelValues(run.stage.Name).Observe(time.Since(run.start).Seconds())
+
+		if err != nil {
+			stageFailures.WithLabelValues(run.stage.Name, failureReason(err)).Inc()
+
+			if panicErr, ok := err.(*PanicError); ok {
+				klog.Errorf("stage %s: %v\n%s", run.stage.Name, err, panicErr.Stack)
+			}
+		}
+	})
+}
+
+// close closes the stage's channel, if not already closed.
+func (run *stageRun) close() {
+	if !run.closed {
+		close(run.ch)
+		run.closed = true
+	}
+}
+
+// feed sends the items to the stage, then closes its channel.
+func (run *stageRun) feed(items []*client.ServiceEndpoints) {
+	for _, item := range items {
+		run.send(item)
+	}
+	run.close()
+}
+
+// wait waits for the end of the run, returning its error.
+func (run *stageRun) wait() error {
+	<-run.done
+	return run.err
+}
+
+// replay sends the items to a new channel, until done is closed.
+func replay(items []*client.ServiceEndpoints, done <-chan struct{}) <-chan *client.ServiceEndpoints {
+	ch := make(chan *client.ServiceEndpoints, 2)
+
+	go func() {
+		defer close(ch)
+
+		for _, item := range items {
+			select {
+			case ch <- item:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return ch
+}
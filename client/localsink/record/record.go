@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package record records the operations sent to a local sink, so they can be replayed later (see kpng replay).
+package record
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/client/localsink"
+)
+
+var resetOp = &localnetv1.OpItem{Op: &localnetv1.OpItem_Reset_{Reset_: &localnetv1.EmptyOp{}}}
+
+// Sink records the operations sent to the wrapped sink. Recording errors are logged and stop the
+// recording, but never fail the wrapped sink.
+type Sink struct {
+	localsink.Sink
+
+	mu     sync.Mutex
+	out    io.Writer
+	buf    *bufio.Writer
+	failed bool
+}
+
+var _ localsink.Sink = &Sink{}
+
+// New returns a Sink recording the operations sent to sink to out.
+func New(sink localsink.Sink, out io.Writer) *Sink {
+	return &Sink{
+		Sink: sink,
+		out:  out,
+		buf:  bufio.NewWriter(out),
+	}
+}
+
+// Create returns a Sink recording the operations sent to sink in a new file.
+func Create(sink localsink.Sink, path string) (*Sink, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(sink, f), nil
+}
+
+func (s *Sink) Reset() {
+	s.record(resetOp)
+	s.Sink.Reset()
+}
+
+func (s *Sink) Send(op *localnetv1.OpItem) error {
+	s.record(op)
+
+	if _, isSync := op.Op.(*localnetv1.OpItem_Sync); isSync {
+		s.flush()
+	}
+
+	return s.Sink.Send(op)
+}
+
+// ApplyStatus forwards the status of the wrapped sink if it's a localsink.StatusReporter.
+func (s *Sink) ApplyStatus() *localnetv1.ApplyStatus {
+	return localsink.ApplyStatusOf(s.Sink)
+}
+
+// Close flushes the recording and closes its output if it's an io.Closer.
+func (s *Sink) Close() error {
+	s.flush()
+
+	if c, ok := s.out.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (s *Sink) record(op *localnetv1.OpItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failed {
+		return
+	}
+
+	err := Write(s.buf, &localnetv1.RecordedOp{Time: time.Now().UnixNano(), Op: op})
+	s.check(err)
+}
+
+func (s *Sink) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failed {
+		return
+	}
+
+	s.check(s.buf.Flush())
+}
+
+func (s *Sink) check(err error) {
+	if err != nil {
+		klog.Error("recording failed, stopping it: ", err)
+		s.failed = true
+	}
+}
+
+// Write writes a length-delimited record.
+func Write(w io.Writer, rec *localnetv1.RecordedOp) error {
+	ba, err := proto.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	size := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(size, uint64(len(ba)))
+
+	if _, err = w.Write(size[:n]); err != nil {
+		return err
+	}
+
+	_, err = w.Write(ba)
+	return err
+}
+
+// maxRecordSize protects from reading garbage as a huge size
+const maxRecordSize = 64 << 20
+
+// Reader reads the records written by a Sink.
+type Reader struct {
+	r *bufio.Reader
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Next returns the next record, or io.EOF at the end of the recording.
+func (r *Reader) Next() (rec *localnetv1.RecordedOp, err error) {
+	size, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return
+	}
+
+	if size > maxRecordSize {
+		return nil, fmt.Errorf("record too large: %d bytes", size)
+	}
+
+	ba := make([]byte, size)
+	if _, err = io.ReadFull(r.r, ba); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return
+	}
+
+	rec = &localnetv1.RecordedOp{}
+	err = proto.Unmarshal(ba, rec)
+	return
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package record
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+)
+
+// printSink prints the calls it receives
+type printSink struct {
+	name string
+}
+
+func (s *printSink) Setup() { fmt.Println(s.name, "setup") }
+
+func (s *printSink) WaitRequest() (string, error) {
+	fmt.Println(s.name, "wait request")
+	return "node-a", nil
+}
+
+func (s *printSink) Reset() { fmt.Println(s.name, "reset") }
+
+func (s *printSink) Send(op *localnetv1.OpItem) error {
+	switch v := op.Op.(type) {
+	case *localnetv1.OpItem_Set:
+		fmt.Println(s.name, "set", v.Set.Ref.Path)
+	case *localnetv1.OpItem_Delete:
+		fmt.Println(s.name, "delete", v.Delete.Path)
+	case *localnetv1.OpItem_Sync:
+		fmt.Println(s.name, "sync")
+	}
+	return nil
+}
+
+func Example() {
+	buf := &bytes.Buffer{}
+	recorder := New(&printSink{name: "live"}, buf)
+
+	set := func(path string) *localnetv1.OpItem {
+		return &localnetv1.OpItem{Op: &localnetv1.OpItem_Set{Set: &localnetv1.Value{
+			Ref: &localnetv1.Ref{Set: localnetv1.Set_ServicesSet, Path: path},
+		}}}
+	}
+	sync := &localnetv1.OpItem{Op: &localnetv1.OpItem_Sync{Sync: &localnetv1.EmptyOp{}}}
+
+	// what a client does with two change sets, the second after a reconnection
+	recorder.Setup()
+	recorder.WaitRequest()
+	recorder.Send(set("default/a"))
+	recorder.Send(set("default/b"))
+	recorder.Send(sync)
+	recorder.WaitRequest()
+	recorder.Reset()
+	recorder.Send(&localnetv1.OpItem{Op: &localnetv1.OpItem_Delete{Delete: &localnetv1.Ref{Path: "default/a"}}})
+	recorder.Send(sync)
+	recorder.Close()
+
+	err := Replay(context.Background(), buf, &printSink{name: "replay"}, 0)
+	if err != nil {
+		fmt.Println("error:", err)
+	}
+
+	// Output:
+	// live setup
+	// live wait request
+	// live set default/a
+	// live set default/b
+	// live sync
+	// live wait request
+	// live reset
+	// live delete default/a
+	// live sync
+	// replay setup
+	// replay wait request
+	// replay set default/a
+	// replay set default/b
+	// replay sync
+	// replay wait request
+	// replay reset
+	// replay delete default/a
+	// replay sync
+}
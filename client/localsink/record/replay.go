@@ -0,0 +1,85 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package record
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/client/localsink"
+)
+
+// Replay sets the sink up and sends it the recorded operations, waiting between them as recorded,
+// accelerated by the speed factor (as fast as possible if 0). As a client does, the sink's
+// WaitRequest is called before each change set.
+func Replay(ctx context.Context, in io.Reader, sink localsink.Sink, speed float64) (err error) {
+	r := NewReader(in)
+
+	sink.Setup()
+
+	var (
+		prevTime    int64
+		waitRequest = true
+	)
+
+	for {
+		var rec *localnetv1.RecordedOp
+		rec, err = r.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return
+		}
+
+		if waitRequest {
+			if _, err = sink.WaitRequest(); err != nil {
+				return
+			}
+			waitRequest = false
+		}
+
+		if delay := rec.Time - prevTime; speed > 0 && prevTime != 0 && delay > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(float64(delay) / speed)):
+			}
+		} else if err = ctx.Err(); err != nil {
+			return
+		}
+
+		prevTime = rec.Time
+
+		switch rec.Op.Op.(type) {
+		case *localnetv1.OpItem_Reset_:
+			sink.Reset()
+
+		case *localnetv1.OpItem_Sync:
+			err = sink.Send(rec.Op)
+			waitRequest = true
+
+		default:
+			err = sink.Send(rec.Op)
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localsink
+
+import (
+	"sync"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+)
+
+// StatusReporter can be implemented by a Sink to report the result of the last apply of its state
+// to the server (see localnetv1.WatchReq.ApplyStatus).
+type StatusReporter interface {
+	// ApplyStatus returns the status of the last apply, or nil if nothing was applied since the last call.
+	ApplyStatus() *localnetv1.ApplyStatus
+}
+
+// ApplyStatusOf returns the status reported by v if it's a StatusReporter, nil otherwise.
+func ApplyStatusOf(v interface{}) *localnetv1.ApplyStatus {
+	if r, ok := v.(StatusReporter); ok {
+		return r.ApplyStatus()
+	}
+	return nil
+}
+
+// Status is a StatusReporter recording the result of the last apply; it can be embedded in sinks.
+type Status struct {
+	mu     sync.Mutex
+	status *localnetv1.ApplyStatus
+}
+
+var _ StatusReporter = &Status{}
+
+// SetApplyResult records the result of an apply, with the services (as namespace/name) that failed if known.
+func (s *Status) SetApplyResult(err error, failedServices ...string) {
+	status := &localnetv1.ApplyStatus{FailedServices: failedServices}
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	s.mu.Lock()
+	s.status = status
+	s.mu.Unlock()
+}
+
+func (s *Status) ApplyStatus() (status *localnetv1.ApplyStatus) {
+	s.mu.Lock()
+	status, s.status = s.status, nil
+	s.mu.Unlock()
+	return
+}
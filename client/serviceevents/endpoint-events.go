@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceevents
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+)
+
+// EndpointsListener receives the endpoints of each service port, with their target port
+// resolved (see localnetv1.Endpoint.PortMapping).
+type EndpointsListener interface {
+	AddPortEndpoint(svc *localnetv1.Service, port *localnetv1.PortMapping, key string, endpoint *localnetv1.Endpoint, targetPort int32)
+	DeletePortEndpoint(svc *localnetv1.Service, port *localnetv1.PortMapping, key string, endpoint *localnetv1.Endpoint, targetPort int32)
+}
+
+// SetEndpoint is called when an endpoint is added or updated
+func (sl *ServicesListener) SetEndpoint(namespace, serviceName, key string, endpoint *localnetv1.Endpoint) {
+	if sl.EndpointsListener == nil {
+		return
+	}
+
+	svcKey := namespace + "/" + serviceName
+
+	eps := sl.endpoints[svcKey]
+	if eps == nil {
+		eps = map[string]*localnetv1.Endpoint{}
+		sl.endpoints[svcKey] = eps
+	}
+
+	prevEp := eps[key]
+	eps[key] = endpoint
+
+	svc := sl.services[svcKey]
+	if svc == nil {
+		return
+	}
+
+	sameIPs := prevEp != nil && proto.Equal(prevEp.IPs, endpoint.IPs)
+
+	for _, port := range svc.Ports {
+		targetPort := endpoint.PortMapping(port)
+
+		if prevEp != nil {
+			prevTargetPort := prevEp.PortMapping(port)
+			if sameIPs && prevTargetPort == targetPort {
+				continue
+			}
+
+			sl.EndpointsListener.DeletePortEndpoint(svc, port, key, prevEp, prevTargetPort)
+		}
+
+		sl.EndpointsListener.AddPortEndpoint(svc, port, key, endpoint, targetPort)
+	}
+}
+
+// DeleteEndpoint is called when an endpoint is deleted
+func (sl *ServicesListener) DeleteEndpoint(namespace, serviceName, key string) {
+	svcKey := namespace + "/" + serviceName
+
+	eps := sl.endpoints[svcKey]
+	prevEp, ok := eps[key]
+	if !ok {
+		return // already removed
+	}
+
+	delete(eps, key)
+	if len(eps) == 0 {
+		delete(sl.endpoints, svcKey)
+	}
+
+	svc := sl.services[svcKey]
+	if svc == nil {
+		return
+	}
+
+	for _, port := range svc.Ports {
+		sl.EndpointsListener.DeletePortEndpoint(svc, port, key, prevEp, prevEp.PortMapping(port))
+	}
+}
+
+// diffPortsEndpoints sends the endpoint deletions caused by a service change, and returns the
+// additions to send once the ports are updated.
+func (sl *ServicesListener) diffPortsEndpoints(prevSvc, currSvc *localnetv1.Service) (adds []func()) {
+	var (
+		svcKey               string
+		prevPorts, currPorts []*localnetv1.PortMapping
+	)
+
+	if prevSvc != nil {
+		svcKey = prevSvc.Namespace + "/" + prevSvc.Name
+		prevPorts = prevSvc.Ports
+	}
+	if currSvc != nil {
+		svcKey = currSvc.Namespace + "/" + currSvc.Name
+		currPorts = currSvc.Ports
+	}
+
+	eps := sl.endpoints[svcKey]
+	if len(eps) == 0 {
+		return
+	}
+
+	// sorted keys for a stable events order
+	keys := make([]string, 0, len(eps))
+	for key := range eps {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	addPort := func(port *localnetv1.PortMapping) {
+		for _, key := range keys {
+			key, ep := key, eps[key]
+			adds = append(adds, func() {
+				sl.EndpointsListener.AddPortEndpoint(currSvc, port, key, ep, ep.PortMapping(port))
+			})
+		}
+	}
+
+	Diff{
+		SameKey: func(pi, ci int) bool {
+			return samePortKey(prevPorts[pi], currPorts[ci])
+		},
+		Added: func(ci int) { addPort(currPorts[ci]) },
+		Updated: func(pi, ci int) {
+			prevPort, currPort := prevPorts[pi], currPorts[ci]
+
+			for _, key := range keys {
+				key, ep := key, eps[key]
+
+				prevTargetPort, targetPort := ep.PortMapping(prevPort), ep.PortMapping(currPort)
+				if prevTargetPort == targetPort {
+					continue
+				}
+
+				sl.EndpointsListener.DeletePortEndpoint(prevSvc, prevPort, key, ep, prevTargetPort)
+				adds = append(adds, func() {
+					sl.EndpointsListener.AddPortEndpoint(currSvc, currPort, key, ep, targetPort)
+				})
+			}
+		},
+		Deleted: func(pi int) {
+			prevPort := prevPorts[pi]
+			for _, key := range keys {
+				ep := eps[key]
+				sl.EndpointsListener.DeletePortEndpoint(prevSvc, prevPort, key, ep, ep.PortMapping(prevPort))
+			}
+		},
+	}.SlicesLen(len(prevPorts), len(currPorts))
+
+	return
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceevents
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+)
+
+type portEndpointsLsnr struct{}
+
+func (_ portEndpointsLsnr) AddPort(svc *localnetv1.Service, port *localnetv1.PortMapping) {
+	fmt.Println("ADD svc:", svc.Name, "port:", port.Port)
+}
+func (_ portEndpointsLsnr) UpdatePort(svc *localnetv1.Service, prevPort, port *localnetv1.PortMapping) {
+	fmt.Println("UPDATE svc:", svc.Name, "port:", port.Port, "target port:", prevPort.TargetPort, "->", port.TargetPort)
+}
+func (_ portEndpointsLsnr) DeletePort(svc *localnetv1.Service, port *localnetv1.PortMapping) {
+	fmt.Println("DEL svc:", svc.Name, "port:", port.Port)
+}
+func (_ portEndpointsLsnr) AddPortEndpoint(svc *localnetv1.Service, port *localnetv1.PortMapping, key string, ep *localnetv1.Endpoint, targetPort int32) {
+	fmt.Println("ADD svc:", svc.Name, "port:", port.Port, "endpoint:", key, ep.IPs.All(), "target port:", targetPort)
+}
+func (_ portEndpointsLsnr) DeletePortEndpoint(svc *localnetv1.Service, port *localnetv1.PortMapping, key string, ep *localnetv1.Endpoint, targetPort int32) {
+	fmt.Println("DEL svc:", svc.Name, "port:", port.Port, "endpoint:", key, ep.IPs.All(), "target port:", targetPort)
+}
+
+func ExampleServicesListener_endpoints() {
+	sl := New()
+	sl.PortsListener = portEndpointsLsnr{}
+	sl.EndpointsListener = portEndpointsLsnr{}
+
+	svc := func(targetPort int32, ports ...int32) *localnetv1.Service {
+		svc := &localnetv1.Service{Namespace: "ns", Name: "svc-1"}
+		for _, port := range ports {
+			svc.Ports = append(svc.Ports, &localnetv1.PortMapping{
+				Name:           fmt.Sprint("p", port),
+				Protocol:       localnetv1.Protocol_TCP,
+				Port:           port,
+				TargetPort:     targetPort,
+				TargetPortName: "http",
+			})
+		}
+		return svc
+	}
+
+	fmt.Println("add endpoint before svc")
+	sl.SetEndpoint("ns", "svc-1", "ep1", &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.2.0.1")})
+
+	fmt.Println("add svc with port 80")
+	sl.SetService(svc(8080, 80))
+
+	fmt.Println("add endpoint with a port override")
+	sl.SetEndpoint("ns", "svc-1", "ep2", &localnetv1.Endpoint{
+		IPs:           localnetv1.NewIPSet("10.2.0.2"),
+		PortOverrides: map[string]int32{"p80": 9090},
+	})
+
+	fmt.Println("update endpoint without change")
+	sl.SetEndpoint("ns", "svc-1", "ep1", &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.2.0.1")})
+
+	fmt.Println("add port 81")
+	sl.SetService(svc(8080, 80, 81))
+
+	fmt.Println("change target port")
+	sl.SetService(svc(8081, 80, 81))
+
+	fmt.Println("delete endpoint")
+	sl.DeleteEndpoint("ns", "svc-1", "ep2")
+
+	fmt.Println("delete svc")
+	sl.DeleteService("ns", "svc-1")
+
+	// Output:
+	// add endpoint before svc
+	// add svc with port 80
+	// ADD svc: svc-1 port: 80
+	// ADD svc: svc-1 port: 80 endpoint: ep1 [10.2.0.1] target port: 8080
+	// add endpoint with a port override
+	// ADD svc: svc-1 port: 80 endpoint: ep2 [10.2.0.2] target port: 9090
+	// update endpoint without change
+	// add port 81
+	// ADD svc: svc-1 port: 81
+	// ADD svc: svc-1 port: 81 endpoint: ep1 [10.2.0.1] target port: 8080
+	// ADD svc: svc-1 port: 81 endpoint: ep2 [10.2.0.2] target port: 8080
+	// change target port
+	// DEL svc: svc-1 port: 80 endpoint: ep1 [10.2.0.1] target port: 8080
+	// DEL svc: svc-1 port: 81 endpoint: ep1 [10.2.0.1] target port: 8080
+	// DEL svc: svc-1 port: 81 endpoint: ep2 [10.2.0.2] target port: 8080
+	// UPDATE svc: svc-1 port: 80 target port: 8080 -> 8081
+	// UPDATE svc: svc-1 port: 81 target port: 8080 -> 8081
+	// ADD svc: svc-1 port: 80 endpoint: ep1 [10.2.0.1] target port: 8081
+	// ADD svc: svc-1 port: 81 endpoint: ep1 [10.2.0.1] target port: 8081
+	// ADD svc: svc-1 port: 81 endpoint: ep2 [10.2.0.2] target port: 8081
+	// delete endpoint
+	// DEL svc: svc-1 port: 80 endpoint: ep2 [10.2.0.2] target port: 9090
+	// DEL svc: svc-1 port: 81 endpoint: ep2 [10.2.0.2] target port: 8081
+	// delete svc
+	// DEL svc: svc-1 port: 80 endpoint: ep1 [10.2.0.1] target port: 8081
+	// DEL svc: svc-1 port: 81 endpoint: ep1 [10.2.0.1] target port: 8081
+	// DEL svc: svc-1 port: 80
+	// DEL svc: svc-1 port: 81
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package serviceevents
 
 import (
+	"google.golang.org/protobuf/proto"
+
 	"sigs.k8s.io/kpng/api/localnetv1"
 )
 
@@ -25,6 +27,12 @@ type PortsListener interface {
 	DeletePort(svc *localnetv1.Service, port *localnetv1.PortMapping)
 }
 
+// PortUpdatesListener can be implemented by a PortsListener to receive the updates of ports
+// (identified by protocol and port), instead of a delete and an add.
+type PortUpdatesListener interface {
+	UpdatePort(svc *localnetv1.Service, prevPort, port *localnetv1.PortMapping)
+}
+
 type IPsListener interface {
 	AddIP(svc *localnetv1.Service, ip string, ipKind IPKind)
 	DeleteIP(svc *localnetv1.Service, ip string, ipKind IPKind)
@@ -45,6 +53,17 @@ type SessionAffinityListener interface {
 	DisableSessionAffinity(svc *localnetv1.Service)
 }
 
+type IPFamiliesListener interface {
+	AddIPFamily(svc *localnetv1.Service, family string)
+	DeleteIPFamily(svc *localnetv1.Service, family string)
+	UpdateIPFamilyPolicy(svc *localnetv1.Service, policy string)
+}
+
+type IPFilterListener interface {
+	AddIPFilter(svc *localnetv1.Service, filter *localnetv1.IPFilter)
+	DeleteIPFilter(svc *localnetv1.Service, filter *localnetv1.IPFilter)
+}
+
 // ServicesListener analyzes updates to the Service set and produced detailed
 // events about the changes.
 //
@@ -52,20 +71,32 @@ type SessionAffinityListener interface {
 // is present, so no cost is associated with ignored events.
 //
 // Event call order is:
+// - DeletePortEndpoint
 // - AddPort
+// - UpdatePort
 // - DeletePort
+// - AddPortEndpoint
 // - AddIP
 // - AddIPPort
 // - DeleteIPPort
 // - DeleteIP
+// - AddIPFamily
+// - UpdateIPFamilyPolicy
+// - DeleteIPFamily
+// - DeleteIPFilter
+// - AddIPFilter
 type ServicesListener struct {
 	PortsListener           PortsListener
 	IPsListener             IPsListener
 	IPPortsListener         IPPortsListener
 	TrafficPolicyListener   TrafficPolicyListener
 	SessionAffinityListener SessionAffinityListener
+	IPFamiliesListener      IPFamiliesListener
+	EndpointsListener       EndpointsListener
+	IPFilterListener        IPFilterListener
 
-	services map[string]*localnetv1.Service
+	services  map[string]*localnetv1.Service
+	endpoints map[string]map[string]*localnetv1.Endpoint
 }
 
 // New creates a new ServicesListener.
@@ -73,7 +104,8 @@ type ServicesListener struct {
 // Reminder: you need to associate listeners for this listener to be useful.
 func New() *ServicesListener {
 	return &ServicesListener{
-		services: map[string]*localnetv1.Service{},
+		services:  map[string]*localnetv1.Service{},
+		endpoints: map[string]map[string]*localnetv1.Endpoint{},
 	}
 }
 
@@ -111,15 +143,39 @@ func (sl *ServicesListener) diff(prevSvc, currSvc *localnetv1.Service) {
 		currPorts = currSvc.Ports
 	}
 
+	var addEndpoints []func()
+	if sl.EndpointsListener != nil {
+		addEndpoints = sl.diffPortsEndpoints(prevSvc, currSvc)
+	}
+
 	if sl.PortsListener != nil {
-		Diff{
-			SameKey: func(pi, ci int) bool {
-				return samePort(prevPorts[pi], currPorts[ci])
-			},
-			Added:   func(ci int) { sl.PortsListener.AddPort(currSvc, currPorts[ci]) },
-			Updated: func(_, _ int) {},
-			Deleted: func(pi int) { sl.PortsListener.DeletePort(prevSvc, prevPorts[pi]) },
-		}.SlicesLen(len(prevPorts), len(currPorts))
+		if ul, ok := sl.PortsListener.(PortUpdatesListener); ok {
+			Diff{
+				SameKey: func(pi, ci int) bool {
+					return samePortKey(prevPorts[pi], currPorts[ci])
+				},
+				Added: func(ci int) { sl.PortsListener.AddPort(currSvc, currPorts[ci]) },
+				Updated: func(pi, ci int) {
+					if !samePort(prevPorts[pi], currPorts[ci]) {
+						ul.UpdatePort(currSvc, prevPorts[pi], currPorts[ci])
+					}
+				},
+				Deleted: func(pi int) { sl.PortsListener.DeletePort(prevSvc, prevPorts[pi]) },
+			}.SlicesLen(len(prevPorts), len(currPorts))
+		} else {
+			Diff{
+				SameKey: func(pi, ci int) bool {
+					return samePort(prevPorts[pi], currPorts[ci])
+				},
+				Added:   func(ci int) { sl.PortsListener.AddPort(currSvc, currPorts[ci]) },
+				Updated: func(_, _ int) {},
+				Deleted: func(pi int) { sl.PortsListener.DeletePort(prevSvc, prevPorts[pi]) },
+			}.SlicesLen(len(prevPorts), len(currPorts))
+		}
+	}
+
+	for _, addEndpoint := range addEndpoints {
+		addEndpoint()
 	}
 
 	ipsExtractors := []struct {
@@ -246,6 +302,70 @@ func (sl *ServicesListener) diff(prevSvc, currSvc *localnetv1.Service) {
 	for _, deferredCall := range deferredCalls {
 		deferredCall()
 	}
+
+	if sl.IPFamiliesListener != nil {
+		sl.diffIPFamilies(prevSvc, currSvc)
+	}
+
+	if sl.IPFilterListener != nil {
+		sl.diffIPFilters(prevSvc, currSvc)
+	}
+}
+
+func (sl *ServicesListener) diffIPFilters(prevSvc, currSvc *localnetv1.Service) {
+	var prevFilters, currFilters []*localnetv1.IPFilter
+
+	if prevSvc != nil {
+		prevFilters = prevSvc.IPFilters
+	}
+	if currSvc != nil {
+		currFilters = currSvc.IPFilters
+	}
+
+	Diff{
+		SameKey: func(pi, ci int) bool { return proto.Equal(prevFilters[pi], currFilters[ci]) },
+		Added:   func(ci int) { sl.IPFilterListener.AddIPFilter(currSvc, currFilters[ci]) },
+		Updated: func(_, _ int) {},
+		Deleted: func(pi int) { sl.IPFilterListener.DeleteIPFilter(prevSvc, prevFilters[pi]) },
+	}.SlicesLen(len(prevFilters), len(currFilters))
+}
+
+func (sl *ServicesListener) diffIPFamilies(prevSvc, currSvc *localnetv1.Service) {
+	var (
+		prevFamilies, currFamilies []string
+		prevPolicy, currPolicy     string
+	)
+
+	if prevSvc != nil {
+		prevFamilies = prevSvc.IPFamilies
+		prevPolicy = prevSvc.IPFamilyPolicy
+	}
+	if currSvc != nil {
+		currFamilies = currSvc.IPFamilies
+		currPolicy = currSvc.IPFamilyPolicy
+	}
+
+	deletedFamilies := make([]string, 0)
+
+	Diff{
+		SameKey: func(pi, ci int) bool { return prevFamilies[pi] == currFamilies[ci] },
+		Added:   func(ci int) { sl.IPFamiliesListener.AddIPFamily(currSvc, currFamilies[ci]) },
+		Updated: func(_, _ int) {},
+		Deleted: func(pi int) { deletedFamilies = append(deletedFamilies, prevFamilies[pi]) },
+	}.SlicesLen(len(prevFamilies), len(currFamilies))
+
+	if currSvc != nil && prevPolicy != currPolicy {
+		sl.IPFamiliesListener.UpdateIPFamilyPolicy(currSvc, currPolicy)
+	}
+
+	for _, family := range deletedFamilies {
+		sl.IPFamiliesListener.DeleteIPFamily(prevSvc, family)
+	}
+}
+
+// samePortKey returns true if both ports are the same service port (protocol and port)
+func samePortKey(p1, p2 *localnetv1.PortMapping) bool {
+	return p1.Protocol == p2.Protocol && p1.Port == p2.Port
 }
 
 func samePort(p1, p2 *localnetv1.PortMapping) bool {
@@ -254,7 +374,8 @@ func samePort(p1, p2 *localnetv1.PortMapping) bool {
 		p1.Port == p2.Port &&
 		p1.NodePort == p2.NodePort &&
 		p1.TargetPort == p2.TargetPort &&
-		p1.TargetPortName == p2.TargetPortName
+		p1.TargetPortName == p2.TargetPortName &&
+		p1.AppProtocol == p2.AppProtocol
 }
 
 // SessionAffinity contains data about assinged session affinity
@@ -326,3 +326,107 @@ func Example() {
 	//     ip: 10.1.1.1 (ClusterIP)
 
 }
+
+type ipFamiliesLsnr struct{}
+
+func (_ ipFamiliesLsnr) AddIPFamily(svc *localnetv1.Service, family string) {
+	fmt.Println("ADD svc:", svc.Name, "family:", family)
+}
+func (_ ipFamiliesLsnr) DeleteIPFamily(svc *localnetv1.Service, family string) {
+	fmt.Println("DEL svc:", svc.Name, "family:", family)
+}
+func (_ ipFamiliesLsnr) UpdateIPFamilyPolicy(svc *localnetv1.Service, policy string) {
+	fmt.Println("UPDATE svc:", svc.Name, "policy:", policy)
+}
+
+func ExampleServicesListener_ipFamilies() {
+	sl := New()
+	sl.IPFamiliesListener = ipFamiliesLsnr{}
+
+	fmt.Println("add single stack svc")
+	sl.SetService(&localnetv1.Service{
+		Namespace:      "ns",
+		Name:           "svc-1",
+		IPFamilyPolicy: "SingleStack",
+		IPFamilies:     []string{"IPv4"},
+	})
+
+	fmt.Println("switch to dual stack")
+	sl.SetService(&localnetv1.Service{
+		Namespace:      "ns",
+		Name:           "svc-1",
+		IPFamilyPolicy: "RequireDualStack",
+		IPFamilies:     []string{"IPv4", "IPv6"},
+	})
+
+	fmt.Println("switch to IPv6 only")
+	sl.SetService(&localnetv1.Service{
+		Namespace:      "ns",
+		Name:           "svc-1",
+		IPFamilyPolicy: "SingleStack",
+		IPFamilies:     []string{"IPv6"},
+	})
+
+	fmt.Println("delete svc")
+	sl.DeleteService("ns", "svc-1")
+
+	// Output:
+	// add single stack svc
+	// ADD svc: svc-1 family: IPv4
+	// UPDATE svc: svc-1 policy: SingleStack
+	// switch to dual stack
+	// ADD svc: svc-1 family: IPv6
+	// UPDATE svc: svc-1 policy: RequireDualStack
+	// switch to IPv6 only
+	// UPDATE svc: svc-1 policy: SingleStack
+	// DEL svc: svc-1 family: IPv4
+	// delete svc
+	// DEL svc: svc-1 family: IPv6
+}
+
+type ipFilterLsnr struct{}
+
+func (_ ipFilterLsnr) AddIPFilter(svc *localnetv1.Service, filter *localnetv1.IPFilter) {
+	fmt.Println("ADD svc:", svc.Name, "filter:", filter.TargetIPs.All(), filter.SourceRanges)
+}
+func (_ ipFilterLsnr) DeleteIPFilter(svc *localnetv1.Service, filter *localnetv1.IPFilter) {
+	fmt.Println("DEL svc:", svc.Name, "filter:", filter.TargetIPs.All(), filter.SourceRanges)
+}
+
+func ExampleServicesListener_ipFilters() {
+	sl := New()
+	sl.IPFilterListener = ipFilterLsnr{}
+
+	svc := func(sourceRanges ...string) *localnetv1.Service {
+		return &localnetv1.Service{
+			Namespace: "ns",
+			Name:      "svc-1",
+			IPFilters: []*localnetv1.IPFilter{{
+				TargetIPs:    localnetv1.NewIPSet("10.3.0.1"),
+				SourceRanges: sourceRanges,
+			}},
+		}
+	}
+
+	fmt.Println("add svc with a source range")
+	sl.SetService(svc("192.168.0.0/16"))
+
+	fmt.Println("update without change")
+	sl.SetService(svc("192.168.0.0/16"))
+
+	fmt.Println("change source ranges")
+	sl.SetService(svc("192.168.1.0/24", "10.0.0.0/8"))
+
+	fmt.Println("delete svc")
+	sl.DeleteService("ns", "svc-1")
+
+	// Output:
+	// add svc with a source range
+	// ADD svc: svc-1 filter: [10.3.0.1] [192.168.0.0/16]
+	// update without change
+	// change source ranges
+	// DEL svc: svc-1 filter: [10.3.0.1] [192.168.0.0/16]
+	// ADD svc: svc-1 filter: [10.3.0.1] [192.168.1.0/24 10.0.0.0/8]
+	// delete svc
+	// DEL svc: svc-1 filter: [10.3.0.1] [192.168.1.0/24 10.0.0.0/8]
+}
@@ -18,6 +18,7 @@ package serviceevents
 
 import (
 	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/client/localsink"
 	"sigs.k8s.io/kpng/client/localsink/decoder"
 )
 
@@ -28,7 +29,10 @@ type wrapper struct {
 	l *ServicesListener
 }
 
-var _ decoder.Interface = wrapper{}
+var (
+	_ decoder.Interface    = wrapper{}
+	_ decoder.NodeListener = wrapper{}
+)
 
 // Wrap a decoder so it receives detailled events depending on which interfaces
 // it implements.
@@ -60,6 +64,15 @@ func Wrap(backend decoder.Interface) decoder.Interface {
 	if v, ok := backend.(TrafficPolicyListener); ok {
 		l.TrafficPolicyListener = v
 	}
+	if v, ok := backend.(IPFamiliesListener); ok {
+		l.IPFamiliesListener = v
+	}
+	if v, ok := backend.(EndpointsListener); ok {
+		l.EndpointsListener = v
+	}
+	if v, ok := backend.(IPFilterListener); ok {
+		l.IPFilterListener = v
+	}
 
 	wrap := wrapper{
 		Interface: backend,
@@ -77,3 +90,29 @@ func (w wrapper) DeleteService(namespace, name string) {
 	w.l.DeleteService(namespace, name)
 	w.Interface.DeleteService(namespace, name)
 }
+
+func (w wrapper) SetEndpoint(namespace, serviceName, key string, endpoint *localnetv1.Endpoint) {
+	w.Interface.SetEndpoint(namespace, serviceName, key, endpoint)
+	w.l.SetEndpoint(namespace, serviceName, key, endpoint)
+}
+
+func (w wrapper) DeleteEndpoint(namespace, serviceName, key string) {
+	w.l.DeleteEndpoint(namespace, serviceName, key)
+	w.Interface.DeleteEndpoint(namespace, serviceName, key)
+}
+
+func (w wrapper) SetNode(node *localnetv1.Node) {
+	if l, ok := w.Interface.(decoder.NodeListener); ok {
+		l.SetNode(node)
+	}
+}
+
+func (w wrapper) DeleteNode(name string) {
+	if l, ok := w.Interface.(decoder.NodeListener); ok {
+		l.DeleteNode(name)
+	}
+}
+
+func (w wrapper) ApplyStatus() *localnetv1.ApplyStatus {
+	return localsink.ApplyStatusOf(w.Interface)
+}
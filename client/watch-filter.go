@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"strings"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+)
+
+// watchFilterFlags are the command-line values of a watch filter
+type watchFilterFlags struct {
+	namespaces         string
+	excludedNamespaces string
+	labelSelector      string
+	serviceTypes       string
+}
+
+func (f *watchFilterFlags) bind(flags FlagSet) {
+	flags.StringVar(&f.namespaces, "watch-namespaces", "", "only watch services in these namespaces (comma-separated)")
+	flags.StringVar(&f.excludedNamespaces, "watch-excluded-namespaces", "", "do not watch services in these namespaces (comma-separated)")
+	flags.StringVar(&f.labelSelector, "watch-label-selector", "", "only watch services matching this label selector")
+	flags.StringVar(&f.serviceTypes, "watch-service-types", "", "only watch services of these types (comma-separated)")
+}
+
+func (f *watchFilterFlags) filter() *localnetv1.WatchFilter {
+	if *f == (watchFilterFlags{}) {
+		return nil
+	}
+
+	return &localnetv1.WatchFilter{
+		Namespaces:         splitList(f.namespaces),
+		ExcludedNamespaces: splitList(f.excludedNamespaces),
+		LabelSelector:      f.labelSelector,
+		ServiceTypes:       splitList(f.serviceTypes),
+	}
+}
+
+func splitList(s string) (values []string) {
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return
+}
+
+// WatchFilter returns the filter to send with watch requests (nil if none).
+func (epc *EndpointsClient) WatchFilter() *localnetv1.WatchFilter {
+	if epc.Filter != nil {
+		return epc.Filter
+	}
+	return epc.filterFlags.filter()
+}
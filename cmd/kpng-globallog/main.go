@@ -22,9 +22,6 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/connectivity"
 	"google.golang.org/protobuf/proto"
 	"k8s.io/klog/v2"
 
@@ -41,147 +38,109 @@ func main() {
 
 	flags := cmd.Flags()
 	flags.BoolVar(&once, "once", false, "run only one loop")
-	epc = client.New(flags)
+	gc = client.NewGlobal(flags)
+
+	// the sets used to be given with --sets, before the client's --watch-sets
+	flags.StringSliceVar(&sets, "sets", nil, "sets to watch (GlobalServiceInfos, GlobalEndpointInfos, GlobalNodeInfos; all if not set)")
+	flags.MarkDeprecated("sets", "use --watch-sets instead")
 
 	cmd.Execute()
 }
 
 var (
-	epc  *client.EndpointsClient
-	conn *grpc.ClientConn
+	gc   *client.GlobalClient
 	once bool
+	sets []string
 )
 
 func run() {
-	if conn != nil {
-		switch conn.GetState() {
-		case connectivity.Shutdown:
-			conn.Close()
-			conn = nil
-		}
-	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	if conn == nil {
-		c, err := epc.Dial()
-		if isCanceled(err) {
-			return
-		} else if err != nil {
-			klog.Info("failed to connect: ", err)
-			time.Sleep(time.Second)
-			return
+	for _, set := range sets {
+		v, ok := localnetv1.Set_value[set]
+		if !ok {
+			klog.Fatal("unknown set: ", set)
 		}
-
-		conn = c
+		gc.Sets = append(gc.Sets, localnetv1.Set(v))
 	}
 
-	ctx := epc.Context()
-
-	cli := localnetv1.NewGlobalClient(conn)
+	gc.Sink = &logSink{cancel: cancel}
 
-	w, err := cli.Watch(ctx)
-	if isCanceled(err) {
-		return
-	} else if err != nil {
-		klog.Info("failed to start the watch: ", err)
-		time.Sleep(time.Second)
-		return
+	err := gc.Run(ctx)
+	if err != nil && err != context.Canceled {
+		klog.Fatal(err)
 	}
+}
 
-	for {
-		err = watchReq(w)
-		if isCanceled(err) {
-			return
-		} else if err != nil {
-			klog.Info("watch request failed: ", err)
-			time.Sleep(time.Second)
-			return
-		}
-
-		if once {
-			break
-		}
-	}
+// logSink prints the changes of the global state
+type logSink struct {
+	cancel func()
+	prevs  map[string]proto.Message
+	start  time.Time
+}
 
-	return
+func (s *logSink) Setup() {
+	s.prevs = map[string]proto.Message{}
 }
 
-var prevs = map[string]proto.Message{}
+func (s *logSink) Reset() {
+	fmt.Println("> reset")
+	s.prevs = map[string]proto.Message{}
+}
 
-func watchReq(w localnetv1.Global_WatchClient) error {
-	fmt.Println("< req (global) at", time.Now())
-	if err := w.Send(&localnetv1.GlobalWatchReq{}); err != nil {
-		return err
+func (s *logSink) Send(op *localnetv1.OpItem) (err error) {
+	if s.start.IsZero() {
+		s.start = time.Now()
+		fmt.Println("< recv at", s.start)
 	}
 
-	start := time.Time{}
+	switch v := op.Op; v.(type) {
+	case *localnetv1.OpItem_Set:
+		set := op.GetSet()
+
+		var v proto.Message
+		switch set.Ref.Set {
+		case localnetv1.Set_GlobalEndpointInfos:
+			v = &localnetv1.EndpointInfo{}
+		case localnetv1.Set_GlobalNodeInfos:
+			v = &localnetv1.NodeInfo{}
+		case localnetv1.Set_GlobalServiceInfos:
+			v = &localnetv1.ServiceInfo{}
+
+		default:
+			klog.Info("unknown set: ", set.Ref.Set)
+			return
+		}
 
-loop:
-	for {
-		op, err := w.Recv()
-		if err != nil {
-			return err
+		if err := proto.Unmarshal(set.Bytes, v); err != nil {
+			klog.Info("failed to parse value: ", err)
+			v = nil
 		}
 
-		if start.IsZero() {
-			start = time.Now()
-			fmt.Println("< recv at", start)
+		refStr := set.Ref.String()
+		if prev, ok := s.prevs[refStr]; ok {
+			fmt.Println("-", refStr, "->", prev)
 		}
+		fmt.Println("+", refStr, "->", v)
 
-		switch v := op.Op; v.(type) {
-		case *localnetv1.OpItem_Set:
-			set := op.GetSet()
-
-			var v proto.Message
-			switch set.Ref.Set {
-			case localnetv1.Set_ServicesSet:
-				v = &localnetv1.Service{}
-			case localnetv1.Set_EndpointsSet:
-				v = &localnetv1.Endpoint{}
-
-			case localnetv1.Set_GlobalEndpointInfos:
-				v = &localnetv1.EndpointInfo{}
-			case localnetv1.Set_GlobalNodeInfos:
-				v = &localnetv1.NodeInfo{}
-			case localnetv1.Set_GlobalServiceInfos:
-				v = &localnetv1.ServiceInfo{}
-
-			default:
-				klog.Info("unknown set: ", set.Ref.Set)
-				continue loop
-			}
-
-			if v != nil {
-				err = proto.Unmarshal(set.Bytes, v)
-				if err != nil {
-					klog.Info("failed to parse value: ", err)
-					v = nil
-				}
-			}
-
-			refStr := set.Ref.String()
-			if prev, ok := prevs[refStr]; ok {
-				fmt.Println("-", refStr, "->", prev)
-			}
-			fmt.Println("+", refStr, "->", v)
-
-			prevs[refStr] = v
-
-		case *localnetv1.OpItem_Delete:
-			refStr := op.GetDelete().String()
-			prev := prevs[refStr]
+		s.prevs[refStr] = v
 
-			fmt.Println("-", refStr, "->", prev)
-			delete(prevs, refStr)
+	case *localnetv1.OpItem_Delete:
+		refStr := op.GetDelete().String()
+		prev := s.prevs[refStr]
+
+		fmt.Println("-", refStr, "->", prev)
+		delete(s.prevs, refStr)
 
-		case *localnetv1.OpItem_Sync:
-			fmt.Println("> sync after", time.Since(start))
-			break loop // done
+	case *localnetv1.OpItem_Sync:
+		fmt.Println("> sync after", time.Since(s.start))
+		s.start = time.Time{}
+
+		if once {
+			s.cancel()
 		}
 	}
 
-	return nil
-}
-
-func isCanceled(err error) bool {
-	return err == context.Canceled || grpc.Code(err) == codes.Canceled
+	return
 }
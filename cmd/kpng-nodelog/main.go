@@ -82,6 +82,8 @@ func (s *sink) Send(op *localnetv1.OpItem) (err error) {
 			v = &localnetv1.Service{}
 		case localnetv1.Set_EndpointsSet:
 			v = &localnetv1.Endpoint{}
+		case localnetv1.Set_NodesSet:
+			v = &localnetv1.Node{}
 
 		case localnetv1.Set_GlobalEndpointInfos:
 			v = &localnetv1.EndpointInfo{}
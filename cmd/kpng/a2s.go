@@ -21,6 +21,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"sigs.k8s.io/kpng/client/apiconn"
 	"sigs.k8s.io/kpng/client/tlsflags"
 	"sigs.k8s.io/kpng/server/jobs/api2store"
 	"sigs.k8s.io/kpng/server/pkg/apiwatch"
@@ -31,7 +32,7 @@ import (
 
 var (
 	api2storeJob = &api2store.Job{
-		Watch: apiwatch.Watch{TLSFlags: &tlsflags.Flags{}},
+		Watch: apiwatch.Watch{Watch: apiconn.Watch{TLSFlags: &tlsflags.Flags{}}},
 	}
 )
 
@@ -44,6 +45,7 @@ func api2storeCmd() *cobra.Command {
 
 	flags := cmd.PersistentFlags()
 	api2storeJob.BindFlags(flags)
+	admissionCfg.BindFlags(flags)
 
 	cmd.AddCommand(storecmds.Commands(setupAPI2store)...)
 
@@ -55,6 +57,12 @@ func setupAPI2store() (ctx context.Context, store *proxystore.Store, err error)
 
 	store = proxystore.New()
 
+	if err = admissionCfg.Setup(store); err != nil {
+		return
+	}
+
+	startMonitoring(ctx, store.Ready)
+
 	api2storeJob.Store = store
 	go api2storeJob.Run(ctx)
 
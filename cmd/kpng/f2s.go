@@ -34,13 +34,14 @@ func file2storeCmd() *cobra.Command {
 	// file to * command
 	k2sCmd := &cobra.Command{
 		Use:   "file",
-		Short: "poll a file to the global state",
+		Short: "watch files to the global state",
 	}
 
 	flags := k2sCmd.PersistentFlags()
-	flags.StringVarP(&f2sInput, "input", "i", "global-state.yaml", "Input file for the global-state")
+	flags.StringVarP(&f2sInput, "input", "i", "global-state.yaml", "Input file for the global-state, or a directory of files to merge (formats by extension: .yaml/.yml, .json or .pb for snapshots)")
 
 	k2sCfg.BindFlags(k2sCmd.PersistentFlags())
+	admissionCfg.BindFlags(k2sCmd.PersistentFlags())
 	k2sCmd.AddCommand(storecmds.Commands(setupFile2store)...)
 
 	return k2sCmd
@@ -52,6 +53,12 @@ func setupFile2store() (ctx context.Context, store *proxystore.Store, err error)
 	// create the store
 	store = proxystore.New()
 
+	if err = admissionCfg.Setup(store); err != nil {
+		return
+	}
+
+	startMonitoring(ctx, store.Ready)
+
 	go (&file2store.Job{
 		FilePath: f2sInput,
 		Store:    store,
@@ -24,12 +24,17 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
 
 	// this depends on the kpng server to run the integrated app
 	"sigs.k8s.io/kpng/server/jobs/kube2store"
+	"sigs.k8s.io/kpng/server/jobs/store2snapshot"
+	"sigs.k8s.io/kpng/server/pkg/admission"
 	"sigs.k8s.io/kpng/server/pkg/proxystore"
+	"sigs.k8s.io/kpng/server/pkg/server/watches"
 )
 
 // FIXME separate package
@@ -37,6 +42,9 @@ var (
 	kubeConfig string
 	kubeServer string
 	k2sCfg     = &kube2store.Config{}
+	snapCfg    = &store2snapshot.Config{}
+
+	admissionCfg = &admission.Config{}
 )
 
 func kube2storeCmd() *cobra.Command {
@@ -51,6 +59,8 @@ func kube2storeCmd() *cobra.Command {
 	flags.StringVar(&kubeServer, "server", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
 
 	k2sCfg.BindFlags(k2sCmd.PersistentFlags())
+	snapCfg.BindFlags(k2sCmd.PersistentFlags())
+	admissionCfg.BindFlags(k2sCmd.PersistentFlags())
 	k2sCmd.AddCommand(storecmds.Commands(setupKube2store)...)
 
 	return k2sCmd
@@ -76,14 +86,50 @@ func setupKube2store() (ctx context.Context, store *proxystore.Store, err error)
 		return
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		err = fmt.Errorf("Error building kubernetes dynamic client: %w", err)
+		return
+	}
+
 	// create the store
 	store = proxystore.New()
 
+	if err = admissionCfg.Setup(store); err != nil {
+		return
+	}
+
+	startMonitoring(ctx, store.Ready)
+
+	if snapCfg.FilePath != "" {
+		// warm start: serve the last known state until the informers are synced
+		if err = snapCfg.Restore(store); err != nil {
+			err = fmt.Errorf("Error restoring snapshot: %w", err)
+			return
+		}
+
+		go func() {
+			err := (&store2snapshot.Job{
+				Store:  store,
+				Config: snapCfg,
+			}).Run(ctx)
+			if err != nil {
+				klog.Error("snapshots stopped: ", err)
+			}
+		}()
+	}
+
+	if k2sCfg.ApplyEvents {
+		watches.DefaultRegistry.AddApplyListener(kube2store.ApplyEventsRecorder(kubeClient))
+	}
+
 	// start kube2store
 	go kube2store.Job{
 		Kube:   kubeClient,
 		Store:  store,
 		Config: k2sCfg,
+
+		Dynamic: dynamicClient,
 	}.Run(ctx)
 
 	return
@@ -38,6 +38,7 @@ func local2sinkCmd() *cobra.Command {
 
 	cmd.AddCommand(storecmds.LocalCmds(func(sink localsink.Sink) (err error) {
 		ctx := setupGlobal()
+		startMonitoring(ctx, nil)
 		job.Sink = sink
 		job.Run(ctx)
 		return
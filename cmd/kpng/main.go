@@ -27,12 +27,15 @@ import (
 
 	"k8s.io/klog/v2"
 
+	"sigs.k8s.io/kpng/server/pkg/monitoring"
 	"sigs.k8s.io/kpng/server/pkg/proxy"
 )
 
 var (
 	cpuprofile = flag.String("cpuprofile", "", "write cpu profile to file")
 	version    = "(unknown)"
+
+	monitoringCfg = &monitoring.Config{}
 )
 
 func main() {
@@ -43,12 +46,14 @@ func main() {
 	}
 
 	cmd.PersistentFlags().AddGoFlagSet(flag.CommandLine)
+	monitoringCfg.BindFlags(cmd.PersistentFlags())
 
 	cmd.AddCommand(
 		kube2storeCmd(), // no-op?
 		file2storeCmd(),
 		api2storeCmd(),
 		local2sinkCmd(),
+		replayCmd(),
 		versionCmd(),
 	)
 
@@ -82,6 +87,19 @@ func setupGlobal() (ctx context.Context) {
 	return
 }
 
+// startMonitoring serves the metrics and health endpoints if enabled; ready gives the readiness (always ready if nil).
+func startMonitoring(ctx context.Context, ready func() bool) {
+	if !monitoringCfg.Enabled() {
+		return
+	}
+
+	go func() {
+		if err := monitoringCfg.Run(ctx, ready); err != nil {
+			klog.Error("monitoring server failed: ", err)
+		}
+	}()
+}
+
 func versionCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
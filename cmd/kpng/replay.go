@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kpng/client/localsink"
+	"sigs.k8s.io/kpng/client/localsink/record"
+	"sigs.k8s.io/kpng/cmd/kpng/storecmds"
+)
+
+func replayCmd() *cobra.Command {
+	// replay to * command
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "replay a recording of a local state stream (see the --record option of the backends)",
+	}
+
+	var (
+		input string
+		speed float64
+	)
+
+	flags := cmd.PersistentFlags()
+	flags.StringVarP(&input, "input", "i", "", "recording to replay")
+	flags.Float64Var(&speed, "speed", 1, "replay speed factor (0 to replay as fast as possible)")
+
+	cmd.AddCommand(storecmds.LocalCmds(func(sink localsink.Sink) (err error) {
+		ctx := setupGlobal()
+		startMonitoring(ctx, nil)
+
+		in, err := os.Open(input)
+		if err != nil {
+			return
+		}
+		defer in.Close()
+
+		return record.Replay(ctx, in, sink, speed)
+	})...)
+
+	return cmd
+}
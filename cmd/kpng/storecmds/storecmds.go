@@ -24,8 +24,10 @@ import (
 
 	"sigs.k8s.io/kpng/client/backendcmd"
 	"sigs.k8s.io/kpng/client/localsink"
+	"sigs.k8s.io/kpng/client/localsink/record"
 
 	"sigs.k8s.io/kpng/server/jobs/store2api"
+	"sigs.k8s.io/kpng/server/jobs/store2diff"
 	"sigs.k8s.io/kpng/server/jobs/store2file"
 	"sigs.k8s.io/kpng/server/jobs/store2localdiff"
 	"sigs.k8s.io/kpng/server/pkg/proxystore"
@@ -98,7 +100,9 @@ func (c SetupFunc) ToLocalCmd() (cmd *cobra.Command) {
 	}
 
 	var ctx context.Context
-	job := &store2localdiff.Job{}
+	job := &store2localdiff.Job{Coalesce: &store2diff.Coalesce{}}
+
+	job.Coalesce.BindFlags(cmd.PersistentFlags())
 
 	cmd.PersistentPreRunE = func(_ *cobra.Command, _ []string) (err error) {
 		ctx, job.Store, err = c()
@@ -117,15 +121,29 @@ func LocalCmds(run func(sink localsink.Sink) error) (cmds []*cobra.Command) {
 	// sink backends
 	for _, useCmd := range backendcmd.Registered() {
 		backend := useCmd.New()
+		recordPath := ""
 
 		cmd := &cobra.Command{
 			Use: useCmd.Use,
 			RunE: func(_ *cobra.Command, _ []string) error {
-				return run(backend.Sink())
+				sink := backend.Sink()
+
+				if recordPath != "" {
+					recorder, err := record.Create(sink, recordPath)
+					if err != nil {
+						return err
+					}
+					defer recorder.Close()
+
+					sink = recorder
+				}
+
+				return run(sink)
 			},
 		}
 
 		backend.BindFlags(cmd.Flags())
+		cmd.Flags().StringVar(&recordPath, "record", "", "record the operations sent to the backend in this file (see the replay command)")
 
 		cmds = append(cmds, cmd)
 	}
@@ -56,7 +56,11 @@ func handleEndpoints(items []*client.ServiceEndpoints) {
 			continue
 		}
 
-		filteredItems = append(filteredItems, item)
+		// only keep endpoints eligible for external traffic
+		filteredItems = append(filteredItems, &client.ServiceEndpoints{
+			Service:   item.Service,
+			Endpoints: item.ExternalEndpoints(),
+		})
 	}
 
 	// table names
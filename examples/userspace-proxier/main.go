@@ -46,5 +46,5 @@ func main() {
 	backend.nodeName = runner.NodeName
 
 	// and run!
-	runner.RunSink(filterreset.New(decoder.New(serviceevents.Wrap(backend))))
+	runner.RunSink(filterreset.New(decoder.New(decoder.InternalOnly(serviceevents.Wrap(backend)))))
 }
@@ -29,6 +29,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/client/apiconn"
 	"sigs.k8s.io/kpng/client/lightdiffstore"
 	"sigs.k8s.io/kpng/server/pkg/server"
 	"sigs.k8s.io/kpng/server/pkg/server/watchstate"
@@ -48,7 +49,7 @@ func main() {
 	rand.Seed(time.Now().UnixNano())
 	instanceID = rand.Uint64()
 
-	srv := grpc.NewServer()
+	srv := grpc.NewServer(apiconn.ServerOptions()...)
 
 	localnetv1.RegisterEndpointsServer(srv, watchSrv{})
 
@@ -18,7 +18,6 @@ package api2local
 
 import (
 	"context"
-	"time"
 
 	"github.com/spf13/pflag"
 	"google.golang.org/grpc"
@@ -27,6 +26,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/client/apiconn"
 	"sigs.k8s.io/kpng/client/localsink"
 	"sigs.k8s.io/kpng/client/tlsflags"
 
@@ -52,13 +52,17 @@ func (c *Config) BindFlags(flags *pflag.FlagSet) {
 type Job struct {
 	apiwatch.Watch
 	Sink localsink.Sink
+
+	// last revision fully received, to resume after reconnections
+	lastRev      *localnetv1.Revision
+	lastNodeName string
 }
 
 func New(sink localsink.Sink) *Job {
 	return &Job{
-		Watch: apiwatch.Watch{
+		Watch: apiwatch.Watch{Watch: apiconn.Watch{
 			TLSFlags: &tlsflags.Flags{},
-		},
+		}},
 		Sink: sink,
 	}
 }
@@ -74,8 +78,12 @@ func (j *Job) Run(ctx context.Context) {
 			return
 		}
 
-		klog.Error("local watch error: ", err)
-		time.Sleep(5 * time.Second) // TODO parameter?
+		if err == apiwatch.ErrFailBack {
+			klog.Info(err)
+		} else {
+			klog.Error("local watch error: ", err)
+		}
+		j.Retry(ctx, err)
 	}
 }
 
@@ -90,7 +98,7 @@ func (j *Job) run(ctx context.Context) (err error) {
 	// watch local state
 	local := localnetv1.NewEndpointsClient(conn)
 
-	watch, err := local.Watch(ctx)
+	watch, err := local.Watch(j.StreamContext(ctx))
 	if err != nil {
 		return
 	}
@@ -112,9 +120,22 @@ func (j *Job) runLoop(watch localnetv1.Endpoints_WatchClient) (err error) {
 
 	nodeName, err := j.Sink.WaitRequest()
 
-	err = watch.Send(&localnetv1.WatchReq{
+	req := &localnetv1.WatchReq{
 		NodeName: nodeName,
-	})
+	}
+
+	if nodeName == j.lastNodeName {
+		req.LastRevision = j.lastRev
+	}
+
+	if j.lastRev != nil {
+		if status := localsink.ApplyStatusOf(j.Sink); status != nil {
+			status.Revision = j.lastRev
+			req.ApplyStatus = status
+		}
+	}
+
+	err = watch.Send(req)
 	if err != nil {
 		return
 	}
@@ -127,6 +148,11 @@ func (j *Job) runLoop(watch localnetv1.Endpoints_WatchClient) (err error) {
 			return
 		}
 
+		j.Received(op)
+
+		// the sink is receiving a change set, its state won't match a revision until the sync
+		j.lastRev = nil
+
 		switch op.Op.(type) {
 		case *localnetv1.OpItem_Reset_:
 			j.Sink.Reset()
@@ -139,7 +165,9 @@ func (j *Job) runLoop(watch localnetv1.Endpoints_WatchClient) (err error) {
 		}
 
 		if _, isSync := op.Op.(*localnetv1.OpItem_Sync); isSync {
-			return
+			j.lastRev = op.Revision
+			j.lastNodeName = nodeName
+			return j.Synced()
 		}
 	}
 }
@@ -18,7 +18,6 @@ package api2store
 
 import (
 	"context"
-	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -34,6 +33,9 @@ import (
 type Job struct {
 	apiwatch.Watch
 	Store *proxystore.Store
+
+	// last revision fully received, to resume after reconnections
+	lastRev *localnetv1.Revision
 }
 
 func (j *Job) Run(ctx context.Context) {
@@ -47,8 +49,12 @@ func (j *Job) Run(ctx context.Context) {
 			return
 		}
 
-		klog.Error("global watch error: ", err)
-		time.Sleep(5 * time.Second) // TODO parameter?
+		if err == apiwatch.ErrFailBack {
+			klog.Info(err)
+		} else {
+			klog.Error("global watch error: ", err)
+		}
+		j.Retry(ctx, err)
 	}
 }
 
@@ -63,7 +69,7 @@ func (j *Job) run(ctx context.Context) (err error) {
 	// watch global state
 	global := localnetv1.NewGlobalClient(conn)
 
-	watch, err := global.Watch(ctx)
+	watch, err := global.Watch(j.StreamContext(ctx))
 	if err != nil {
 		return
 	}
@@ -74,7 +80,7 @@ func (j *Job) run(ctx context.Context) (err error) {
 			return
 		}
 
-		watch.Send(&localnetv1.GlobalWatchReq{})
+		watch.Send(&localnetv1.GlobalWatchReq{LastRevision: j.lastRev})
 
 		todo := make([]func(tx *proxystore.Tx), 0)
 
@@ -87,6 +93,8 @@ func (j *Job) run(ctx context.Context) (err error) {
 				return
 			}
 
+			j.Received(op)
+
 			var storeOp func(tx *proxystore.Tx)
 
 			switch v := op.Op.(type) {
@@ -125,6 +133,9 @@ func (j *Job) run(ctx context.Context) (err error) {
 				}
 
 			case *localnetv1.OpItem_Sync:
+				// changes are only applied on sync, so we can resume from here
+				j.lastRev = op.Revision
+
 				// break on sync
 				break recvLoop
 			}
@@ -149,5 +160,9 @@ func (j *Job) run(ctx context.Context) (err error) {
 				tx.SetSync(proxystore.Nodes)
 			})
 		}
+
+		if err = j.Synced(); err != nil {
+			return
+		}
 	}
 }
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api2store
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/client/apiconn"
+	"sigs.k8s.io/kpng/server/pkg/admission"
+	"sigs.k8s.io/kpng/server/pkg/apiwatch"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+type testGlobalServer struct {
+	localnetv1.UnimplementedGlobalServer
+}
+
+func (_ testGlobalServer) Watch(w localnetv1.Global_WatchServer) error {
+	set := func(set localnetv1.Set, path string, v proto.Message) error {
+		b, _ := proto.Marshal(v)
+		return w.Send(&localnetv1.OpItem{Op: &localnetv1.OpItem_Set{Set: &localnetv1.Value{
+			Ref:   &localnetv1.Ref{Set: set, Path: path},
+			Bytes: b,
+		}}})
+	}
+
+	if _, err := w.Recv(); err != nil {
+		return err
+	}
+
+	endpoint := func(ip string) *localnetv1.EndpointInfo {
+		return &localnetv1.EndpointInfo{Namespace: "ns", SourceName: "svc-1-abc", ServiceName: "svc-1",
+			Endpoint: &localnetv1.Endpoint{IPs: &localnetv1.IPSet{V4: []string{ip}}}}
+	}
+	valid, invalid := endpoint("10.2.0.1"), endpoint("10.2.0.256")
+
+	for _, err := range []error{
+		w.Send(&localnetv1.OpItem{Op: &localnetv1.OpItem_Reset_{}}),
+		set(localnetv1.Set_GlobalServiceInfos, "ns|svc-1||", &localnetv1.ServiceInfo{Service: &localnetv1.Service{Namespace: "ns", Name: "svc-1"}}),
+		set(localnetv1.Set_GlobalEndpointInfos, "ns|svc-1|svc-1-abc|h1", valid),
+		set(localnetv1.Set_GlobalEndpointInfos, "ns||svc-1-abc|h1", valid),
+		set(localnetv1.Set_GlobalEndpointInfos, "ns|svc-1|svc-1-abc|h2", invalid),
+		set(localnetv1.Set_GlobalEndpointInfos, "ns||svc-1-abc|h2", invalid),
+		w.Send(&localnetv1.OpItem{Op: &localnetv1.OpItem_Sync{}, Revision: &localnetv1.Revision{Rev: 1}}),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	// wait for the client to leave
+	_, err := w.Recv()
+	return err
+}
+
+func TestAdmission(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := grpc.NewServer()
+	localnetv1.RegisterGlobalServer(srv, testGlobalServer{})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	store := proxystore.New()
+	if err := (&admission.Config{Validate: true}).Setup(store); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go (&Job{Watch: apiwatch.Watch{Watch: apiconn.Watch{Server: lis.Addr().String()}}, Store: store}).Run(ctx)
+
+	var ips []string
+	store.View(0, func(tx *proxystore.Tx) {
+		tx.Each(proxystore.Endpoints, func(kv *proxystore.KV) bool {
+			ips = append(ips, kv.Endpoint.Endpoint.IPs.All()...)
+			return true
+		})
+	})
+
+	// the valid endpoint, indexed by service and by source
+	if len(ips) != 2 || ips[0] != "10.2.0.1" || ips[1] != "10.2.0.1" {
+		t.Errorf("expected only the valid endpoint, got %v", ips)
+	}
+}
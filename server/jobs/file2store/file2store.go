@@ -18,13 +18,13 @@ package file2store
 
 import (
 	"context"
-	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/cespare/xxhash"
-	"gopkg.in/yaml.v2"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kpng/api/localnetv1"
@@ -36,53 +36,203 @@ import (
 )
 
 type Job struct {
+	// FilePath is the input file, or a directory of input files merged together (see Decode for the formats)
 	FilePath string
 	Store    *proxystore.Store
 }
 
 func (j *Job) Run(ctx context.Context) {
-	configPath := j.FilePath
-	store := j.Store
+	in := newInputs(j.FilePath)
 
-	w := watchstate.New(nil, proxystore.AllSets)
+	changes := in.watch(ctx)
 
-	mtime := time.Time{}
-
-	for range time.Tick(time.Second) {
-		if ctx.Err() != nil {
+	for {
+		select {
+		case <-ctx.Done():
 			return
+		case <-changes:
+		}
+
+		// let the writes settle
+		time.Sleep(settleDelay)
+		select {
+		case <-changes:
+		default:
+		}
+
+		if in.reload() {
+			in.apply(j.Store)
+		}
+	}
+}
+
+// inputFile is the last known state of an input file
+type inputFile struct {
+	modTime time.Time
+	size    int64
+
+	// state is the last valid state of the file (nil if it was never valid)
+	state *store2file.GlobalState
+	// err is the error of the last load
+	err error
+}
+
+type inputs struct {
+	path  string
+	files map[string]*inputFile
+
+	w *watchstate.WatchState
+}
+
+func newInputs(path string) *inputs {
+	return &inputs{
+		path:  path,
+		files: map[string]*inputFile{},
+		w:     watchstate.New(nil, proxystore.AllSets),
+	}
+}
+
+// paths returns the input files to load
+func (in *inputs) paths() (paths []string, err error) {
+	stat, err := os.Stat(in.path)
+	if err != nil {
+		return
+	}
+
+	if !stat.IsDir() {
+		return []string{in.path}, nil
+	}
+
+	entries, err := os.ReadDir(in.path)
+	if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name[0] == '.' || !IsInputFile(name) {
+			continue
 		}
+		paths = append(paths, filepath.Join(in.path, name))
+	}
 
-		stat, err := os.Stat(configPath)
+	return
+}
+
+// reload loads the input files that changed, returning true if the merged state must be applied again.
+// A file that can't be loaded keeps its last valid state.
+func (in *inputs) reload() (changed bool) {
+	paths, err := in.paths()
+	if err != nil {
+		klog.Info("failed to list the input files: ", err)
+		return
+	}
+
+	seen := make(map[string]bool, len(paths))
+
+	for _, p := range paths {
+		seen[p] = true
+
+		file := in.files[p]
+		if file == nil {
+			file = &inputFile{}
+			in.files[p] = file
+		}
+
+		stat, err := os.Stat(p)
 		if err != nil {
-			klog.Info("failed to stat config: ", err)
+			in.failed(p, file, err)
 			continue
 		}
 
-		if !stat.ModTime().After(mtime) {
+		if stat.ModTime().Equal(file.modTime) && stat.Size() == file.size {
 			continue
 		}
 
-		mtime = stat.ModTime()
+		file.modTime = stat.ModTime()
+		file.size = stat.Size()
 
-		configBytes, err := ioutil.ReadFile(configPath)
+		data, err := os.ReadFile(p)
 		if err != nil {
-			klog.Info("failed to read config: ", err)
+			in.failed(p, file, err)
 			continue
 		}
 
-		state := &store2file.GlobalState{}
-		err = yaml.UnmarshalStrict(configBytes, state)
+		state, err := Decode(p, data)
 		if err != nil {
-			klog.Info("failed to parse config: ", err)
+			in.failed(p, file, err)
+			continue
+		}
+
+		klog.Info("loaded ", p)
+		file.state = state
+		file.err = nil
+		changed = true
+	}
+
+	for p, file := range in.files {
+		if seen[p] {
 			continue
 		}
 
-		diffNodes := w.StoreFor(proxystore.Nodes)
-		diffSvcs := w.StoreFor(proxystore.Services)
-		diffEPs := w.StoreFor(proxystore.Endpoints)
+		klog.Info("removed ", p)
+		delete(in.files, p)
+
+		if file.state != nil {
+			changed = true
+		}
+	}
+
+	return
+}
+
+func (in *inputs) failed(path string, file *inputFile, err error) {
+	if file.err == nil || file.err.Error() != err.Error() {
+		if file.state == nil {
+			klog.Errorf("failed to load %s: %v", path, err)
+		} else {
+			klog.Errorf("failed to load %s, keeping its last valid state: %v", path, err)
+		}
+	}
+	file.err = err
+
+	// retry on the next change, even if the file looks the same
+	file.modTime = time.Time{}
+}
+
+// apply merges the states of the input files and applies it to the store.
+func (in *inputs) apply(store *proxystore.Store) {
+	paths := make([]string, 0, len(in.files))
+	for p, file := range in.files {
+		if file.state != nil {
+			paths = append(paths, p)
+		}
+	}
+
+	if len(paths) == 0 && len(in.files) != 0 {
+		return // nothing valid to apply yet
+	}
+
+	sort.Strings(paths)
+
+	diffNodes := in.w.StoreFor(proxystore.Nodes)
+	diffSvcs := in.w.StoreFor(proxystore.Services)
+	diffEPs := in.w.StoreFor(proxystore.Endpoints)
+
+	// origin of each node and service, to report duplicates
+	origins := map[string]string{}
+	setOrigin := func(key, path string) {
+		if prev, dup := origins[key]; dup {
+			klog.Warningf("%s: %s is already defined in %s, overriding it", path, key, prev)
+		}
+		origins[key] = path
+	}
+
+	for _, p := range paths {
+		state := in.files[p].state
 
 		for _, node := range state.Nodes {
+			setOrigin("node "+node.Name, p)
 			diffNodes.Set([]byte(node.Name), serde.Hash(node), node)
 		}
 
@@ -99,6 +249,7 @@ func (j *Job) Run(ctx context.Context) {
 
 			fullName := []byte(svc.Namespace + "/" + svc.Name)
 
+			setOrigin("service "+string(fullName), p)
 			diffSvcs.Set(fullName, serde.Hash(si), si)
 
 			if len(se.Endpoints) != 0 {
@@ -118,47 +269,47 @@ func (j *Job) Run(ctx context.Context) {
 				diffEPs.Set(fullName, h.Sum64(), se.Endpoints)
 			}
 		}
+	}
 
-		store.Update(func(tx *proxystore.Tx) {
-			for _, u := range diffNodes.Updated() {
-				klog.Info("U node ", string(u.Key))
-				tx.SetNode(u.Value.(*localnetv1.Node))
-			}
-			for _, u := range diffSvcs.Updated() {
-				klog.Info("U service ", string(u.Key))
-				si := u.Value.(*localnetv1.ServiceInfo)
-				tx.SetService(si.Service)
-			}
-			for _, u := range diffEPs.Updated() {
-				klog.Info("U endpoints ", string(u.Key))
-				key := string(u.Key)
-				eis := u.Value.([]*localnetv1.EndpointInfo)
-
-				tx.SetEndpointsOfSource(path.Dir(key), path.Base(key), eis)
-			}
+	store.Update(func(tx *proxystore.Tx) {
+		for _, u := range diffNodes.Updated() {
+			klog.Info("U node ", string(u.Key))
+			tx.SetNode(u.Value.(*localnetv1.Node))
+		}
+		for _, u := range diffSvcs.Updated() {
+			klog.Info("U service ", string(u.Key))
+			si := u.Value.(*localnetv1.ServiceInfo)
+			tx.SetService(si.Service)
+		}
+		for _, u := range diffEPs.Updated() {
+			klog.Info("U endpoints ", string(u.Key))
+			key := string(u.Key)
+			eis := u.Value.([]*localnetv1.EndpointInfo)
 
-			for _, d := range diffEPs.Deleted() {
-				klog.Info("D endpoints ", string(d.Key))
-				key := string(d.Key)
-				tx.DelEndpointsOfSource(path.Dir(key), path.Base(key))
-			}
-			for _, d := range diffSvcs.Deleted() {
-				klog.Info("D service ", string(d.Key))
-				key := string(d.Key)
-				tx.DelService(path.Dir(key), path.Base(key))
-			}
-			for _, d := range diffNodes.Deleted() {
-				klog.Info("D node ", string(d.Key))
-				tx.DelNode(string(d.Key))
-			}
+			tx.SetEndpointsOfSource(path.Dir(key), path.Base(key), eis)
+		}
 
-			for _, set := range proxystore.AllSets {
-				tx.SetSync(set)
-			}
-		})
+		for _, d := range diffEPs.Deleted() {
+			klog.Info("D endpoints ", string(d.Key))
+			key := string(d.Key)
+			tx.DelEndpointsOfSource(path.Dir(key), path.Base(key))
+		}
+		for _, d := range diffSvcs.Deleted() {
+			klog.Info("D service ", string(d.Key))
+			key := string(d.Key)
+			tx.DelService(path.Dir(key), path.Base(key))
+		}
+		for _, d := range diffNodes.Deleted() {
+			klog.Info("D node ", string(d.Key))
+			tx.DelNode(string(d.Key))
+		}
 
 		for _, set := range proxystore.AllSets {
-			w.StoreFor(set).Reset(lightdiffstore.ItemDeleted)
+			tx.SetSync(set)
 		}
+	})
+
+	for _, set := range proxystore.AllSets {
+		in.w.StoreFor(set).Reset(lightdiffstore.ItemDeleted)
 	}
 }
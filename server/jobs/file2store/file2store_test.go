@@ -0,0 +1,235 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file2store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/jobs/store2file"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+func TestInputsDirectory(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	services := func(store *proxystore.Store) (names []string) {
+		store.View(0, func(tx *proxystore.Tx) {
+			tx.Each(proxystore.Services, func(kv *proxystore.KV) bool {
+				names = append(names, kv.Namespace+"/"+kv.Name)
+				return true
+			})
+		})
+		sort.Strings(names)
+		return
+	}
+
+	write("a.yaml", "services:\n- service: {namespace: ns-a, name: svc-a}\n")
+	write("b.json", `{"Services": [{"Service": {"Namespace": "ns-b", "Name": "svc-b"}}]}`)
+	write("c.yaml", "not: [valid")
+	write("README.md", "ignored")
+
+	store := proxystore.New()
+	in := newInputs(dir)
+
+	if !in.reload() {
+		t.Fatal("expected changes")
+	}
+	in.apply(store)
+
+	if names := services(store); len(names) != 2 || names[0] != "ns-a/svc-a" || names[1] != "ns-b/svc-b" {
+		t.Fatalf("unexpected services: %v", names)
+	}
+
+	if in.files[filepath.Join(dir, "c.yaml")].err == nil {
+		t.Error("expected c.yaml to be reported as invalid")
+	}
+
+	// a bad update keeps the last valid state of the file
+	write("a.yaml", "services: {")
+	in.reload()
+	in.apply(store)
+
+	if names := services(store); len(names) != 2 {
+		t.Fatalf("expected the last valid state of a.yaml to be kept: %v", names)
+	}
+
+	// a removed file's values are removed
+	os.Remove(filepath.Join(dir, "b.json"))
+	if !in.reload() {
+		t.Fatal("expected changes")
+	}
+	in.apply(store)
+
+	if names := services(store); len(names) != 1 || names[0] != "ns-a/svc-a" {
+		t.Fatalf("unexpected services: %v", names)
+	}
+}
+
+func TestWatchConfigMapUpdate(t *testing.T) {
+	dir := t.TempDir()
+
+	// a configmap volume, as mounted by the kubelet
+	writeData := func(version, content string) {
+		dataDir := filepath.Join(dir, "..v"+version)
+		if err := os.Mkdir(dataDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dataDir, "state.yaml"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(filepath.Base(dataDir), filepath.Join(dir, "..data_tmp")); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeData("1", "services:\n- service: {namespace: ns-a, name: svc-a}\n")
+	if err := os.Symlink("..data/state.yaml", filepath.Join(dir, "state.yaml")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{dir, filepath.Join(dir, "state.yaml")} {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		in := newInputs(path)
+		changes := in.watch(ctx)
+
+		<-changes // initial notification
+		if !in.reload() {
+			t.Fatal(path, ": expected changes")
+		}
+
+		writeData("2"+filepath.Base(path), "services:\n- service: {namespace: ns-b, name: svc-b}\n")
+
+		select {
+		case <-changes:
+		case <-time.After(5 * time.Second):
+			t.Fatal(path, ": the update was not seen")
+		}
+
+		if !in.reload() {
+			t.Error(path, ": expected changes")
+		}
+
+		cancel()
+	}
+}
+
+func TestDecodeSnapshot(t *testing.T) {
+	store := proxystore.New()
+	store.Update(func(tx *proxystore.Tx) {
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "web"})
+		tx.SetEndpointsOfSource("default", "web", []*localnetv1.EndpointInfo{
+			{Namespace: "default", SourceName: "web", ServiceName: "web", Endpoint: &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.2.0.1")}},
+			{Namespace: "default", SourceName: "web", ServiceName: "web", Endpoint: &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.2.0.2")}},
+		})
+	})
+
+	var snapshot *localnetv1.StoreSnapshot
+	store.View(0, func(tx *proxystore.Tx) {
+		snapshot, _ = tx.Snapshot()
+	})
+
+	ba, err := proto.Marshal(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := Decode("state.pb", ba)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(state.Services) != 1 || len(state.Services[0].Endpoints) != 2 {
+		t.Errorf("unexpected state: %+v", state)
+	}
+}
+
+func TestDecodeJSONFromStore2file(t *testing.T) {
+	svc := &localnetv1.Service{
+		Namespace:       "default",
+		Name:            "sticky",
+		Type:            "ClusterIP",
+		IPs:             &localnetv1.ServiceIPs{ClusterIPs: localnetv1.NewIPSet("10.1.0.1")},
+		Ports:           []*localnetv1.PortMapping{{Protocol: localnetv1.Protocol_TCP, Port: 80, TargetPort: 8080}},
+		SessionAffinity: &localnetv1.Service_ClientIP{ClientIP: &localnetv1.ClientIPAffinity{TimeoutSeconds: 600}},
+	}
+
+	store := proxystore.New()
+	store.Update(func(tx *proxystore.Tx) {
+		tx.SetService(svc)
+		tx.SetEndpointsOfSource("default", "sticky", []*localnetv1.EndpointInfo{
+			{Namespace: "default", SourceName: "sticky", ServiceName: "sticky", Endpoint: &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.2.0.1")}},
+		})
+
+		for _, set := range proxystore.AllSets {
+			tx.SetSync(set)
+		}
+	})
+	defer store.Close()
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	go (&store2file.Job{Store: store, Config: &store2file.Config{FilePath: path}}).Run(context.Background())
+
+	var ba []byte
+	for i := 0; ; i++ {
+		var err error
+		if ba, err = os.ReadFile(path); err == nil {
+			break
+		}
+		if i == 100 {
+			t.Fatal("state not written: ", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	state, err := Decode(path, ba)
+	if err != nil {
+		t.Fatalf("failed to decode: %v\n%s", err, string(ba))
+	}
+
+	if len(state.Services) != 1 || !proto.Equal(svc, state.Services[0].Service) || len(state.Services[0].Endpoints) != 1 {
+		t.Errorf("round-trip failed:\n%s", string(ba))
+	}
+}
+
+func TestDecodeWithoutExtension(t *testing.T) {
+	state, err := Decode("/etc/kpng/state", []byte("services:\n- service: {namespace: default, name: web}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(state.Services) != 1 || state.Services[0].Service.Name != "web" {
+		t.Errorf("unexpected state: %+v", state)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file2store
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v2"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/jobs/store2file"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+// decoders of the input files, by extension
+var decoders = map[string]func(data []byte) (*store2file.GlobalState, error){
+	".yaml": decodeYAML,
+	".yml":  decodeYAML,
+	".json": decodeJSON,
+	".pb":   decodeSnapshot,
+}
+
+// IsInputFile returns true if the file has the extension of a supported format.
+func IsInputFile(path string) bool {
+	_, ok := decoders[strings.ToLower(filepath.Ext(path))]
+	return ok
+}
+
+// Decode decodes an input file, its format depending on its extension:
+//   - .yaml and .yml: a store2file.GlobalState in YAML
+//   - .json: a store2file.GlobalState in JSON (the proto messages in protojson)
+//   - .pb: a localnetv1.StoreSnapshot (as saved by the --snapshot option)
+//   - any other extension: YAML, as when a single input file is given (only the files of an input directory
+//     are filtered with IsInputFile)
+func Decode(path string, data []byte) (*store2file.GlobalState, error) {
+	decode, ok := decoders[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		decode = decodeYAML
+	}
+
+	return decode(data)
+}
+
+func decodeYAML(data []byte) (state *store2file.GlobalState, err error) {
+	state = &store2file.GlobalState{}
+	err = yaml.UnmarshalStrict(data, state)
+	return
+}
+
+func decodeJSON(data []byte) (state *store2file.GlobalState, err error) {
+	// unknown fields are rejected by the state's UnmarshalJSON
+	state = &store2file.GlobalState{}
+	err = json.Unmarshal(data, state)
+	return
+}
+
+func decodeSnapshot(data []byte) (state *store2file.GlobalState, err error) {
+	snapshot := &localnetv1.StoreSnapshot{}
+	if err = proto.Unmarshal(data, snapshot); err != nil {
+		return
+	}
+
+	state = &store2file.GlobalState{}
+
+	services := map[string]int{} // index in state.Services by namespace/name
+	endpoints := map[string][]*localnetv1.EndpointInfo{}
+
+	for _, value := range snapshot.Values {
+		switch value.Ref.Set {
+		case proxystore.Services:
+			si := &localnetv1.ServiceInfo{}
+			if err = proto.Unmarshal(value.Bytes, si); err != nil {
+				return
+			}
+
+			svc := si.Service
+			services[svc.Namespace+"/"+svc.Name] = len(state.Services)
+			state.Services = append(state.Services, store2file.ServiceAndEndpoints{Service: svc})
+
+		case proxystore.Endpoints:
+			// paths are namespace|name|source|key, the entries indexed by source (without name) having a twin indexed by service
+			if p := strings.Split(value.Ref.Path, "|"); len(p) < 2 || p[1] == "" {
+				continue
+			}
+
+			ei := &localnetv1.EndpointInfo{}
+			if err = proto.Unmarshal(value.Bytes, ei); err != nil {
+				return
+			}
+
+			key := ei.Namespace + "/" + ei.ServiceName
+			endpoints[key] = append(endpoints[key], ei)
+
+		case proxystore.Nodes:
+			ni := &localnetv1.NodeInfo{}
+			if err = proto.Unmarshal(value.Bytes, ni); err != nil {
+				return
+			}
+
+			state.Nodes = append(state.Nodes, ni.Node)
+
+		default:
+			return nil, fmt.Errorf("unknown set in snapshot: %v", value.Ref.Set)
+		}
+	}
+
+	for key, eis := range endpoints {
+		if idx, ok := services[key]; ok {
+			state.Services[idx].Endpoints = eis
+		}
+	}
+
+	return
+}
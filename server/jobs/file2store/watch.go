@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file2store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"k8s.io/klog/v2"
+)
+
+var (
+	// settleDelay is the time given to the writes of a file to complete before loading it
+	settleDelay = 100 * time.Millisecond
+
+	// pollInterval is the interval between checks of the input files when they can't be watched
+	pollInterval = time.Second
+)
+
+// watch returns a channel notified when the input files may have changed (and once at the start).
+// The changes are watched using inotify if possible, by polling otherwise.
+func (in *inputs) watch(ctx context.Context) <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+
+	notify()
+
+	// watch the directory of the input file, to see it when replaced (ie: by editors or kubelet's configmap updates,
+	// swapping the ..data symlink the file links to)
+	isDir := false
+	if stat, err := os.Stat(in.path); err == nil {
+		isDir = stat.IsDir()
+	}
+
+	dir := in.path
+	if !isDir {
+		dir = filepath.Dir(in.path)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err == nil {
+		err = watcher.Add(dir)
+		if err != nil {
+			watcher.Close()
+		}
+	}
+
+	if err != nil {
+		klog.Warning("can't watch ", dir, ", polling every ", pollInterval, ": ", err)
+
+		go func() {
+			ticker := time.NewTicker(pollInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					notify()
+				}
+			}
+		}()
+
+		return ch
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				// any change in the directory may change the input files through symlinks;
+				// the reload only loads the files that really changed
+				klog.V(1).Info("input may have changed: ", event)
+				notify()
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				// events may have been lost, check everything
+				klog.Warning("input watch error: ", err)
+				notify()
+			}
+		}
+	}()
+
+	return ch
+}
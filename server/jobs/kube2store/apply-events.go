@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube2store
+
+import (
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	localnetv1 "sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/pkg/server/watches"
+)
+
+// ApplyEventsRecorder returns an ApplyListener recording the nodes' apply failures as Kubernetes events,
+// on the failed services if known, on the node otherwise.
+func ApplyEventsRecorder(kube kubernetes.Interface) watches.ApplyListener {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kube.CoreV1().Events("")})
+
+	recorder := broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "kpng"})
+
+	return func(nodeName string, status *localnetv1.ApplyStatus) {
+		node := &v1.ObjectReference{Kind: "Node", Name: nodeName}
+
+		if status.Error == "" {
+			recorder.Eventf(node, v1.EventTypeNormal, "ApplySucceeded", "kpng rules applied on node %s", nodeName)
+			return
+		}
+
+		if len(status.FailedServices) == 0 {
+			recorder.Eventf(node, v1.EventTypeWarning, "ApplyFailed", "failed to apply kpng rules: %s", status.Error)
+			return
+		}
+
+		for _, svc := range status.FailedServices {
+			parts := strings.SplitN(svc, "/", 2)
+			if len(parts) != 2 {
+				continue
+			}
+
+			ref := &v1.ObjectReference{Kind: "Service", APIVersion: "v1", Namespace: parts[0], Name: parts[1]}
+			recorder.Eventf(ref, v1.EventTypeWarning, "ApplyFailed", "failed to apply kpng rules on node %s: %s", nodeName, status.Error)
+		}
+	}
+}
@@ -57,7 +57,8 @@ func (h *endpointsEventHandler) OnAdd(obj interface{}) {
 						},
 						Topology: &localnetv1.TopologyInfo{},
 						Conditions: &localnetv1.EndpointConditions{
-							Ready: set.ready,
+							Ready:   set.ready,
+							Serving: set.ready,
 						},
 					}
 
@@ -75,6 +76,8 @@ func (h *endpointsEventHandler) OnAdd(obj interface{}) {
 						info.PodName = t.Name
 					}
 
+					info.Endpoint.Weight = h.endpointWeight(eps.Namespace, info.PodName, eps.Annotations)
+
 					if addr.IP != "" {
 						info.Endpoint.AddAddress(addr.IP)
 					}
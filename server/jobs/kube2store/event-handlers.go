@@ -17,15 +17,35 @@ limitations under the License.
 package kube2store
 
 import (
-	"k8s.io/client-go/tools/cache"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	proxystore "sigs.k8s.io/kpng/server/pkg/proxystore"
 )
 
+// hasSynced is implemented by informers (and groups of informers)
+type hasSynced interface {
+	HasSynced() bool
+}
+
+// allSynced is synced when all its informers are
+type allSynced []hasSynced
+
+func (a allSynced) HasSynced() bool {
+	for _, s := range a {
+		if !s.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
 type eventHandler struct {
 	config   *Config
 	s        *proxystore.Store
-	informer cache.SharedIndexInformer
+	informer hasSynced
 	syncSet  bool
+
+	// pods is only set when endpoint weights are read from pod annotations
+	pods corelisters.PodLister
 }
 
 func (h *eventHandler) updateSync(set proxystore.Set, tx *proxystore.Tx) {
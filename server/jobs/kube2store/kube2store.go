@@ -27,8 +27,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 )
@@ -38,11 +41,25 @@ type Config struct {
 
 	ServiceProxyName string
 
+	WithHeadlessServices bool
+
+	WithServiceImports bool
+
+	EndpointWeightAnnotation string
+
 	ServiceLabelGlobs      []string
 	ServiceAnnonationGlobs []string
 
 	NodeLabelGlobs      []string
 	NodeAnnotationGlobs []string
+
+	Namespaces        []string
+	NamespaceSelector string
+
+	ResyncPeriod     time.Duration
+	NodeResyncPeriod time.Duration
+
+	ApplyEvents bool
 }
 
 //TODO: need to find a better home for this
@@ -57,6 +74,12 @@ func (c *Config) BindFlags(flags *pflag.FlagSet) {
 
 	flags.StringVar(&c.ServiceProxyName, "service-proxy-name", "", "the "+LabelServiceProxyName+" match to use (handle normal services if not set)")
 
+	flags.BoolVar(&c.WithHeadlessServices, "with-headless-services", false, "include headless services (ignored by the backends, but useful to API consumers)")
+
+	flags.BoolVar(&c.WithServiceImports, "with-service-imports", false, "include multi-cluster ServiceImports (as services named "+ServiceImportNamePrefix+"<name>) and their EndpointSlices")
+
+	flags.StringVar(&c.EndpointWeightAnnotation, "endpoint-weight-annotation", "", "the pod, EndpointSlice or Endpoints annotation giving the load-balancing weight of endpoints (weights are disabled if not set)")
+
 	flags.StringSliceVar(&c.ServiceLabelGlobs, "with-service-labels", nil, "service labels to include")
 	flags.StringSliceVar(&c.ServiceAnnonationGlobs, "with-service-annotations", nil, "service annotations to include")
 
@@ -64,56 +87,163 @@ func (c *Config) BindFlags(flags *pflag.FlagSet) {
 		"kubernetes.io/hostname", "topology.kubernetes.io/zone", "topology.kubernetes.io/region",
 	}, "node labels to include")
 	flags.StringSliceVar(&c.NodeAnnotationGlobs, "with-node-annotations", nil, "node annotations to include")
+
+	flags.StringSliceVar(&c.Namespaces, "namespaces", nil, "namespaces to watch services and endpoints in (all if not set)")
+	flags.StringVar(&c.NamespaceSelector, "namespace-selector", "", "label selector of the namespaces to watch services and endpoints in (all if not set)")
+
+	flags.DurationVar(&c.ResyncPeriod, "resync-period", 30*time.Second, "resync period of the services and endpoints informers (0 to disable)")
+	flags.DurationVar(&c.NodeResyncPeriod, "node-resync-period", 30*time.Second, "resync period of the nodes informer (0 to disable)")
+
+	flags.BoolVar(&c.ApplyEvents, "apply-events", false, "record the apply failures reported by the nodes as events on the affected services (or nodes)")
 }
 
 type Job struct {
 	Kube   *kubernetes.Clientset
 	Store  *proxystore.Store
 	Config *Config
+
+	// Dynamic is the client used to watch the resources without typed clients (ie: ServiceImports)
+	Dynamic dynamic.Interface
 }
 
 func (j Job) Run(ctx context.Context) {
 	stopCh := ctx.Done()
 
 	// start informers
-	factory := informers.NewSharedInformerFactoryWithOptions(j.Kube, time.Second*30)
+	factory := informers.NewSharedInformerFactoryWithOptions(j.Kube, j.Config.NodeResyncPeriod)
 	factory.Start(stopCh)
 
+	// start watches
+	{
+		nodesInformer := factory.Core().V1().Nodes().Informer()
+		nodesInformer.SetTransform(j.transformNode)
+		nodesInformer.AddEventHandler(&nodeEventHandler{j.eventHandler(nodesInformer)})
+		go nodesInformer.Run(stopCh)
+	}
+
 	labelSelector := j.getLabelSelector().String()
 	klog.Info("service label selector: ", labelSelector)
-	svcFactory := informers.NewSharedInformerFactoryWithOptions(j.Kube, time.Second*30,
-		informers.WithTweakListOptions(func(options *metav1.ListOptions) { options.LabelSelector = labelSelector }))
-	svcFactory.Start(stopCh)
 
-	// start watches
+	if len(j.Config.Namespaces) == 0 && j.Config.NamespaceSelector == "" {
+		for _, watched := range j.watchNamespace(metav1.NamespaceAll, labelSelector, nil) {
+			watched.informer.AddEventHandler(watched.handler)
+			go watched.informer.Run(stopCh)
+		}
+	} else {
+		j.watchNamespaces(stopCh, labelSelector)
+	}
+
+	_, _ = <-stopCh
+	j.Store.Close()
+}
+
+// informerHandler is an informer with its (not yet added) event handler
+type informerHandler struct {
+	informer cache.SharedIndexInformer
+	handler  cache.ResourceEventHandler
+}
+
+// watchNamespace prepares the informers of the services and endpoints of a namespace (all if empty).
+// The sets are synced using the given synced check, or using each informer if nil.
+func (j Job) watchNamespace(namespace, labelSelector string, synced hasSynced) (watched []informerHandler) {
+	resync := j.Config.ResyncPeriod
+
+	factory := informers.NewSharedInformerFactoryWithOptions(j.Kube, resync, informers.WithNamespace(namespace))
 	coreFactory := factory.Core().V1()
 
+	svcFactory := informers.NewSharedInformerFactoryWithOptions(j.Kube, resync, informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) { options.LabelSelector = labelSelector }))
+
+	handlerFor := func(informer hasSynced) eventHandler {
+		if synced != nil {
+			return j.eventHandler(synced)
+		}
+		return j.eventHandler(informer)
+	}
+
 	{
 		servicesInformer := svcFactory.Core().V1().Services().Informer()
-		servicesInformer.AddEventHandler(&serviceEventHandler{j.eventHandler(servicesInformer)})
-		go servicesInformer.Run(stopCh)
+		servicesInformer.SetTransform(j.transformService)
+
+		var importsInformer cache.SharedIndexInformer
+		if j.Config.WithServiceImports {
+			if j.Dynamic == nil {
+				klog.Error("no dynamic client, not watching service imports")
+			} else {
+				dynFactory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(j.Dynamic, resync, namespace, nil)
+
+				importsInformer = dynFactory.ForResource(serviceImportsResource).Informer()
+				importsInformer.SetTransform(j.transformServiceImport)
+			}
+		}
 
-		nodesInformer := coreFactory.Nodes().Informer()
-		nodesInformer.AddEventHandler(&nodeEventHandler{j.eventHandler(nodesInformer)})
-		go nodesInformer.Run(stopCh)
+		// both fill the services set, so it's synced when both are
+		var servicesSynced hasSynced = servicesInformer
+		if importsInformer != nil {
+			servicesSynced = allSynced{servicesInformer, importsInformer}
+		}
+
+		watched = append(watched, informerHandler{servicesInformer, &serviceEventHandler{handlerFor(servicesSynced)}})
+
+		if importsInformer != nil {
+			watched = append(watched, informerHandler{importsInformer, &serviceImportEventHandler{handlerFor(servicesSynced)}})
+		}
+	}
+
+	var pods corelisters.PodLister
+	var podsInformer cache.SharedIndexInformer
+	if j.Config.EndpointWeightAnnotation != "" {
+		// pods are only watched to read their weight annotation
+		podsFactory := coreFactory.Pods()
+		podsInformer = podsFactory.Informer()
+		podsInformer.SetTransform(j.transformPod)
+		pods = podsFactory.Lister()
 	}
 
+	var sources informerHandler
 	if j.Config.UseSlices {
 		slicesInformer := factory.Discovery().V1().EndpointSlices().Informer()
-		slicesInformer.AddEventHandler(&sliceEventHandler{j.eventHandler(slicesInformer)})
-		go slicesInformer.Run(stopCh)
+		slicesInformer.SetTransform(j.transformEndpointSlice)
+		handler := handlerFor(withPods(slicesInformer, podsInformer))
+		handler.pods = pods
+		sources = informerHandler{slicesInformer, &sliceEventHandler{handler}}
 
 	} else {
 		endpointsInformer := coreFactory.Endpoints().Informer()
-		endpointsInformer.AddEventHandler(&endpointsEventHandler{j.eventHandler(endpointsInformer)})
-		go endpointsInformer.Run(stopCh)
+		endpointsInformer.SetTransform(j.transformEndpoints)
+		handler := handlerFor(withPods(endpointsInformer, podsInformer))
+		handler.pods = pods
+		sources = informerHandler{endpointsInformer, &endpointsEventHandler{handler}}
 	}
 
-	_, _ = <-stopCh
-	j.Store.Close()
+	watched = append(watched, sources)
+
+	if podsInformer != nil {
+		// refresh the endpoints of the pods when their weight changes
+		if err := sources.informer.AddIndexers(cache.Indexers{podsIndex: podsIndexFunc}); err != nil {
+			klog.Error("failed to index the endpoints by pod, weights won't follow the pods' updates: ", err)
+		}
+
+		watched = append(watched, informerHandler{podsInformer, &podEventHandler{
+			annotation: j.Config.EndpointWeightAnnotation,
+			sources:    sources.informer.GetIndexer(),
+			handler:    sources.handler,
+		}})
+	}
+
+	return
 }
 
-func (j Job) eventHandler(informer cache.SharedIndexInformer) eventHandler {
+// withPods returns a synced check of the endpoints informer that also waits for the pods informer (if any),
+// so the endpoints set is not synced before the endpoints' weights are known.
+func withPods(informer, pods cache.SharedIndexInformer) hasSynced {
+	if pods == nil {
+		return informer
+	}
+	return allSynced{informer, pods}
+}
+
+func (j Job) eventHandler(informer hasSynced) eventHandler {
 	return eventHandler{
 		config:   j.Config,
 		s:        j.Store,
@@ -139,7 +269,9 @@ func (j Job) getLabelSelector() labels.Selector {
 		addReq(LabelServiceProxyName, selection.Equals, proxyName)
 	}
 
-	addReq(v1.IsHeadlessService, selection.DoesNotExist)
+	if !j.Config.WithHeadlessServices {
+		addReq(v1.IsHeadlessService, selection.DoesNotExist)
+	}
 
 	return labelSelector
 }
@@ -0,0 +1,43 @@
+package kube2store
+
+import (
+	"testing"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestWatchNamespaceServicesSynced(t *testing.T) {
+	// the informers are not run, the clients never connect
+	cfg := &rest.Config{Host: "http://127.0.0.1:1"}
+
+	j := Job{
+		Kube:    kubernetes.NewForConfigOrDie(cfg),
+		Dynamic: dynamic.NewForConfigOrDie(cfg),
+		Config:  &Config{WithServiceImports: true},
+	}
+
+	checked := 0
+	for _, watched := range j.watchNamespace("", "", nil) {
+		var handler eventHandler
+		switch h := watched.handler.(type) {
+		case *serviceEventHandler:
+			handler = h.eventHandler
+		case *serviceImportEventHandler:
+			handler = h.eventHandler
+		default:
+			continue
+		}
+
+		// the services set is synced once both the services and the imports are
+		if synced, ok := handler.informer.(allSynced); !ok || len(synced) != 2 {
+			t.Errorf("%T: unexpected synced check: %T", watched.handler, handler.informer)
+		}
+		checked++
+	}
+
+	if checked != 2 {
+		t.Errorf("expected the services and imports handlers, got %d", checked)
+	}
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube2store
+
+import (
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+// namespacesWatch watches the services and endpoints of a set of namespaces (given by --namespaces and/or
+// selected by --namespace-selector), each namespace having its own informers. This way, the objects of the
+// other namespaces are not even received.
+type namespacesWatch struct {
+	job           Job
+	stopCh        <-chan struct{}
+	labelSelector string
+	// allowed namespaces (all if nil)
+	allowed map[string]bool
+	// namespacesSynced checks that the initial namespaces are known
+	namespacesSynced func() bool
+
+	mu         sync.Mutex
+	namespaces map[string]*namespaceWatch
+}
+
+type namespaceWatch struct {
+	// mu serializes the events of this namespace with its removal
+	mu      sync.Mutex
+	stopCh  chan struct{}
+	stopped bool
+	watched []informerHandler
+}
+
+func (j Job) watchNamespaces(stopCh <-chan struct{}, labelSelector string) {
+	w := &namespacesWatch{
+		job:              j,
+		stopCh:           stopCh,
+		labelSelector:    labelSelector,
+		namespacesSynced: func() bool { return true },
+		namespaces:       map[string]*namespaceWatch{},
+	}
+
+	if len(j.Config.Namespaces) != 0 {
+		w.allowed = make(map[string]bool, len(j.Config.Namespaces))
+		for _, ns := range j.Config.Namespaces {
+			w.allowed[ns] = true
+		}
+	}
+
+	if selector := j.Config.NamespaceSelector; selector == "" {
+		for ns := range w.allowed {
+			w.add(ns)
+		}
+	} else {
+		klog.Info("namespace label selector: ", selector)
+
+		factory := informers.NewSharedInformerFactoryWithOptions(j.Kube, 0,
+			informers.WithTweakListOptions(func(options *metav1.ListOptions) { options.LabelSelector = selector }))
+
+		// namespaces not matching the selector anymore are received as deleted
+		nsInformer := factory.Core().V1().Namespaces().Informer()
+		nsInformer.SetTransform(j.transformNamespace)
+		nsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				w.add(obj.(*v1.Namespace).Name)
+			},
+			DeleteFunc: func(obj interface{}) {
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				if ns, ok := obj.(*v1.Namespace); ok {
+					w.remove(ns.Name)
+				}
+			},
+		})
+		w.namespacesSynced = nsInformer.HasSynced
+		go nsInformer.Run(stopCh)
+	}
+
+	go func() {
+		<-stopCh
+		w.stopAll()
+	}()
+
+	// some namespaces may have no service or endpoint, so the sets won't be synced by the events
+	go func() {
+		if !cache.WaitForCacheSync(stopCh, w.HasSynced) {
+			return
+		}
+
+		w.job.Store.Update(func(tx *proxystore.Tx) {
+			tx.SetSync(proxystore.Services)
+			tx.SetSync(proxystore.Endpoints)
+		})
+	}()
+}
+
+// HasSynced returns true when the initial namespaces are known and all their informers are synced.
+func (w *namespacesWatch) HasSynced() bool {
+	if !w.namespacesSynced() {
+		return false
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, nsw := range w.namespaces {
+		for _, watched := range nsw.watched {
+			if !watched.informer.HasSynced() {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (w *namespacesWatch) add(namespace string) {
+	if w.allowed != nil && !w.allowed[namespace] {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	select {
+	case <-w.stopCh:
+		return // stopping
+	default:
+	}
+
+	if _, ok := w.namespaces[namespace]; ok {
+		return
+	}
+
+	klog.Info("watching namespace ", namespace)
+
+	nsw := &namespaceWatch{
+		stopCh:  make(chan struct{}),
+		watched: w.job.watchNamespace(namespace, w.labelSelector, w),
+	}
+
+	for _, watched := range nsw.watched {
+		watched.informer.AddEventHandler(nsw.guard(watched.handler))
+		go watched.informer.Run(nsw.stopCh)
+	}
+
+	w.namespaces[namespace] = nsw
+}
+
+func (w *namespacesWatch) remove(namespace string) {
+	w.mu.Lock()
+	nsw, ok := w.namespaces[namespace]
+	delete(w.namespaces, namespace)
+	w.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	klog.Info("not watching namespace ", namespace, " anymore")
+
+	nsw.mu.Lock()
+	defer nsw.mu.Unlock()
+
+	if !nsw.stop() {
+		return // already stopped
+	}
+
+	// remove the namespace's objects from the store
+	for _, watched := range nsw.watched {
+		if _, ok := watched.handler.(*podEventHandler); ok {
+			// the pods have no value in the store, and refreshing their endpoints would set them again
+			continue
+		}
+
+		for _, obj := range watched.informer.GetStore().List() {
+			watched.handler.OnDelete(obj)
+		}
+	}
+}
+
+// stopAll stops watching the namespaces, leaving their objects in the store (it's being closed).
+func (w *namespacesWatch) stopAll() {
+	w.mu.Lock()
+	namespaces := w.namespaces
+	w.namespaces = map[string]*namespaceWatch{}
+	w.mu.Unlock()
+
+	for _, nsw := range namespaces {
+		nsw.mu.Lock()
+		nsw.stop()
+		nsw.mu.Unlock()
+	}
+}
+
+// stop stops the informers and the handlers of the namespace, returning false if they were already
+// stopped. nsw.mu must be held.
+func (nsw *namespaceWatch) stop() bool {
+	if nsw.stopped {
+		return false
+	}
+
+	nsw.stopped = true
+	close(nsw.stopCh)
+	return true
+}
+
+// guard ensures the handler doesn't receive events once the namespace is removed.
+func (nsw *namespaceWatch) guard(handler cache.ResourceEventHandler) cache.ResourceEventHandler {
+	do := func(f func()) {
+		nsw.mu.Lock()
+		defer nsw.mu.Unlock()
+
+		if !nsw.stopped {
+			f()
+		}
+	}
+
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { do(func() { handler.OnAdd(obj) }) },
+		UpdateFunc: func(oldObj, newObj interface{}) { do(func() { handler.OnUpdate(oldObj, newObj) }) },
+		DeleteFunc: func(obj interface{}) { do(func() { handler.OnDelete(obj) }) },
+	}
+}
@@ -0,0 +1,26 @@
+package kube2store
+
+import (
+	"testing"
+)
+
+func TestNamespacesWatchRemoveAfterStop(t *testing.T) {
+	w := &namespacesWatch{namespaces: map[string]*namespaceWatch{
+		"default": {stopCh: make(chan struct{})},
+	}}
+
+	nsw := w.namespaces["default"]
+
+	w.stopAll()
+
+	if !nsw.stopped {
+		t.Error("expected the namespace to be stopped")
+	}
+
+	// a delete received after the stop
+	w.remove("default")
+
+	// an entry stopped but still registered
+	w.namespaces["default"] = nsw
+	w.remove("default")
+}
@@ -44,6 +44,23 @@ func (h *nodeEventHandler) OnAdd(obj interface{}) {
 		Annotations: globsFilter(node.Annotations, h.config.NodeAnnotationGlobs),
 	}
 
+	// network facts
+	for _, addr := range node.Status.Addresses {
+		n.Addresses = append(n.Addresses, &localnetv1.NodeAddress{
+			Type:    string(addr.Type),
+			Address: addr.Address,
+		})
+	}
+
+	// PodCIDRs with backward compatibility (k8s before PodCIDRs)
+	if len(node.Spec.PodCIDRs) == 0 {
+		if node.Spec.PodCIDR != "" {
+			n.PodCIDRs = []string{node.Spec.PodCIDR}
+		}
+	} else {
+		n.PodCIDRs = node.Spec.PodCIDRs
+	}
+
 	h.s.Update(func(tx *proxystore.Tx) {
 		tx.SetNode(n)
 
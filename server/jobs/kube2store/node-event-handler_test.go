@@ -0,0 +1,55 @@
+package kube2store
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+func TestNodeEventHandlerNetworkFacts(t *testing.T) {
+	store := proxystore.New()
+
+	handler := nodeEventHandler{
+		eventHandler: eventHandler{
+			s:       store,
+			syncSet: true,
+			config:  &Config{UseSlices: true},
+		},
+	}
+
+	handler.OnAdd(&v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+		Spec: v1.NodeSpec{
+			PodCIDR:  "10.1.0.0/24",
+			PodCIDRs: []string{"10.1.0.0/24", "fd00:1::/64"},
+		},
+		Status: v1.NodeStatus{
+			Addresses: []v1.NodeAddress{
+				{Type: v1.NodeHostName, Address: "node-a"},
+				{Type: v1.NodeInternalIP, Address: "192.168.1.10"},
+				{Type: v1.NodeInternalIP, Address: "fd00::10"},
+			},
+		},
+	})
+
+	store.View(0, func(tx *proxystore.Tx) {
+		node := tx.GetNode("node-a")
+		if node == nil {
+			t.Fatal("node not found")
+		}
+
+		if s := fmt.Sprint(node.PodCIDRs); s != "[10.1.0.0/24 fd00:1::/64]" {
+			t.Errorf("unexpected pod CIDRs: %s", s)
+		}
+		if len(node.Addresses) != 3 {
+			t.Errorf("expected 3 addresses, got %d", len(node.Addresses))
+		}
+		if ips := node.IPs(); fmt.Sprint(ips.V4, ips.V6) != "[192.168.1.10] [fd00::10]" {
+			t.Errorf("unexpected IPs: %v", ips)
+		}
+	})
+}
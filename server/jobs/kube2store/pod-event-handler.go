@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube2store
+
+import (
+	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// podsIndex indexes the endpoints sources (EndpointSlices or Endpoints) by the pods they target (namespace/name)
+const podsIndex = "pods"
+
+func podsIndexFunc(obj interface{}) (keys []string, err error) {
+	switch eps := obj.(type) {
+	case *discovery.EndpointSlice:
+		for _, ep := range eps.Endpoints {
+			if t := ep.TargetRef; t != nil && t.Kind == "Pod" {
+				keys = append(keys, eps.Namespace+"/"+t.Name)
+			}
+		}
+
+	case *v1.Endpoints:
+		for _, subset := range eps.Subsets {
+			for _, addrs := range [][]v1.EndpointAddress{subset.Addresses, subset.NotReadyAddresses} {
+				for _, addr := range addrs {
+					if t := addr.TargetRef; t != nil && t.Kind == "Pod" {
+						keys = append(keys, eps.Namespace+"/"+t.Name)
+					}
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// podEventHandler refreshes the endpoints of a pod when its weight annotation changes.
+type podEventHandler struct {
+	annotation string
+	// sources are the endpoints sources, indexed by podsIndex
+	sources cache.Indexer
+	// handler is the endpoints sources' event handler
+	handler cache.ResourceEventHandler
+}
+
+func (h *podEventHandler) OnAdd(obj interface{}) {
+	pod := obj.(*v1.Pod)
+
+	if _, ok := pod.Annotations[h.annotation]; ok {
+		h.refresh(pod)
+	}
+}
+
+func (h *podEventHandler) OnUpdate(oldObj, newObj interface{}) {
+	oldPod, newPod := oldObj.(*v1.Pod), newObj.(*v1.Pod)
+
+	oldWeight, oldOk := oldPod.Annotations[h.annotation]
+	newWeight, newOk := newPod.Annotations[h.annotation]
+
+	if oldOk != newOk || oldWeight != newWeight {
+		h.refresh(newPod)
+	}
+}
+
+func (h *podEventHandler) OnDelete(oldObj interface{}) {
+	if tombstone, ok := oldObj.(cache.DeletedFinalStateUnknown); ok {
+		oldObj = tombstone.Obj
+	}
+
+	pod, ok := oldObj.(*v1.Pod)
+	if !ok {
+		return
+	}
+
+	if _, ok := pod.Annotations[h.annotation]; ok {
+		h.refresh(pod)
+	}
+}
+
+// refresh sends again the endpoints sources targeting the pod, so their endpoints get its current weight.
+func (h *podEventHandler) refresh(pod *v1.Pod) {
+	sources, err := h.sources.ByIndex(podsIndex, pod.Namespace+"/"+pod.Name)
+	if err != nil {
+		return
+	}
+
+	for _, source := range sources {
+		h.handler.OnUpdate(source, source)
+	}
+}
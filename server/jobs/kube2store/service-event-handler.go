@@ -67,6 +67,25 @@ func (h *serviceEventHandler) onChange(obj interface{}) {
 		}
 	}
 
+	if service.IPs.Headless && !h.config.WithHeadlessServices {
+		// not included, but it may have been included before (ie: switched from ExternalName)
+		h.OnDelete(svc)
+		return
+	}
+
+	if svc.Spec.Type == v1.ServiceTypeExternalName {
+		service.ExternalName = svc.Spec.ExternalName
+	}
+
+	// IP families
+	if svc.Spec.IPFamilyPolicy != nil {
+		service.IPFamilyPolicy = string(*svc.Spec.IPFamilyPolicy)
+	}
+
+	for _, family := range svc.Spec.IPFamilies {
+		service.IPFamilies = append(service.IPFamilies, string(family))
+	}
+
 	// session affinity info
 	switch svc.Spec.SessionAffinity {
 	case "ClientIP":
@@ -113,6 +132,10 @@ func (h *serviceEventHandler) onChange(obj interface{}) {
 			p.TargetPortName = port.TargetPort.StrVal
 		}
 
+		if port.AppProtocol != nil {
+			p.AppProtocol = *port.AppProtocol
+		}
+
 		service.Ports = append(service.Ports, p)
 	}
 
@@ -6,6 +6,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"sigs.k8s.io/kpng/api/localnetv1"
 	"sigs.k8s.io/kpng/server/pkg/proxystore"
 )
 
@@ -62,6 +63,87 @@ func TestServiceEventHandlerTrafficPolicy(t *testing.T) {
 	}
 }
 
+func TestServiceEventHandlerHeadlessAndExternalName(t *testing.T) {
+	for _, withHeadless := range []bool{false, true} {
+		store := proxystore.New()
+
+		handler := serviceEventHandler{
+			eventHandler: eventHandler{
+				s:       store,
+				syncSet: true,
+				config:  &Config{WithHeadlessServices: withHeadless},
+			},
+		}
+
+		handler.onChange(&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "headless"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeClusterIP, ClusterIP: "None"},
+		})
+		handler.onChange(&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ext"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeExternalName, ExternalName: "example.com"},
+		})
+
+		services := map[string]*localnetv1.Service{}
+		store.View(0, func(tx *proxystore.Tx) {
+			tx.Each(proxystore.Services, func(kv *proxystore.KV) bool {
+				services[kv.Name] = kv.Service.Service
+				return true
+			})
+		})
+
+		if headless := services["headless"]; (headless != nil) != withHeadless {
+			t.Errorf("with headless %v: got headless service %v", withHeadless, headless)
+		}
+
+		if ext := services["ext"]; ext == nil {
+			t.Errorf("with headless %v: ExternalName service not found", withHeadless)
+		} else if ext.ExternalName != "example.com" {
+			t.Errorf("with headless %v: expected external name example.com, got %q", withHeadless, ext.ExternalName)
+		}
+	}
+}
+
+func TestServiceEventHandlerIPFamiliesAndAppProtocol(t *testing.T) {
+	store := proxystore.New()
+
+	handler := serviceEventHandler{
+		eventHandler: eventHandler{
+			s:       store,
+			syncSet: true,
+			config:  &Config{},
+		},
+	}
+
+	handler.onChange(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dual"},
+		Spec: v1.ServiceSpec{
+			Type:           v1.ServiceTypeClusterIP,
+			ClusterIPs:     []string{"10.1.0.1", "fd00::1"},
+			IPFamilyPolicy: ref(v1.IPFamilyPolicyRequireDualStack),
+			IPFamilies:     []v1.IPFamily{v1.IPv4Protocol, v1.IPv6Protocol},
+			Ports:          []v1.ServicePort{{Name: "http", Port: 80, AppProtocol: ref("http")}},
+		},
+	})
+
+	store.View(0, func(tx *proxystore.Tx) {
+		tx.Each(proxystore.Services, func(kv *proxystore.KV) bool {
+			svc := kv.Service.Service
+
+			if svc.IPFamilyPolicy != "RequireDualStack" {
+				t.Errorf("expected policy RequireDualStack, got %q", svc.IPFamilyPolicy)
+			}
+			if len(svc.IPFamilies) != 2 || svc.IPFamilies[0] != "IPv4" || svc.IPFamilies[1] != "IPv6" {
+				t.Errorf("expected families [IPv4 IPv6], got %v", svc.IPFamilies)
+			}
+			if appProto := svc.Ports[0].AppProtocol; appProto != "http" {
+				t.Errorf("expected app protocol http, got %q", appProto)
+			}
+			return true
+		})
+	})
+}
+
 func ref[T any](v T) *T {
 	return &v
 }
@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube2store
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+const (
+	// LabelMultiClusterServiceName is the label of EndpointSlices giving the ServiceImport they belong to.
+	LabelMultiClusterServiceName = "multicluster.kubernetes.io/service-name"
+
+	// ServiceImportNamePrefix is prepended to the name of a ServiceImport to give the name of its service
+	// in the store, so it doesn't collide with the exported service of the same name.
+	// Service names being DNS labels, they can't contain a dot.
+	ServiceImportNamePrefix = "clusterset."
+
+	serviceImportTypeHeadless = "Headless"
+)
+
+var serviceImportsResource = schema.GroupVersionResource{
+	Group:    "multicluster.x-k8s.io",
+	Version:  "v1alpha1",
+	Resource: "serviceimports",
+}
+
+// serviceImport is the subset of the MCS API ServiceImport used here
+type serviceImport struct {
+	Namespace   string
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+
+	Spec struct {
+		IPs   []string `json:"ips"`
+		Type  string   `json:"type"`
+		Ports []struct {
+			Name        string  `json:"name"`
+			Protocol    string  `json:"protocol"`
+			AppProtocol *string `json:"appProtocol"`
+			Port        int32   `json:"port"`
+		} `json:"ports"`
+		SessionAffinity       v1.ServiceAffinity        `json:"sessionAffinity"`
+		SessionAffinityConfig *v1.SessionAffinityConfig `json:"sessionAffinityConfig"`
+	} `json:"spec"`
+}
+
+func serviceImportFrom(obj interface{}) (si *serviceImport, ok bool) {
+	if tombstone, isTombstone := obj.(cache.DeletedFinalStateUnknown); isTombstone {
+		obj = tombstone.Obj
+	}
+
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+
+	si = &serviceImport{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, si); err != nil {
+		klog.Error("invalid ServiceImport ", u.GetNamespace(), "/", u.GetName(), ": ", err)
+		return nil, false
+	}
+
+	si.Namespace = u.GetNamespace()
+	si.Name = u.GetName()
+	si.Labels = u.GetLabels()
+	si.Annotations = u.GetAnnotations()
+
+	return
+}
+
+type serviceImportEventHandler struct{ eventHandler }
+
+func (h *serviceImportEventHandler) onChange(obj interface{}) {
+	si, ok := serviceImportFrom(obj)
+	if !ok {
+		return
+	}
+
+	// build the service
+	service := &localnetv1.Service{
+		Namespace:   si.Namespace,
+		Name:        ServiceImportNamePrefix + si.Name,
+		Type:        string(v1.ServiceTypeClusterIP),
+		Labels:      globsFilter(si.Labels, h.config.ServiceLabelGlobs),
+		Annotations: globsFilter(si.Annotations, h.config.ServiceAnnonationGlobs),
+		IPs: &localnetv1.ServiceIPs{
+			ClusterIPs: localnetv1.NewIPSet(si.Spec.IPs...),
+			Headless:   si.Spec.Type == serviceImportTypeHeadless,
+		},
+	}
+
+	if service.IPs.Headless && !h.config.WithHeadlessServices {
+		h.OnDelete(obj)
+		return
+	}
+
+	// session affinity info
+	if si.Spec.SessionAffinity == v1.ServiceAffinityClientIP {
+		timeout := v1.DefaultClientIPServiceAffinitySeconds
+		if cfg := si.Spec.SessionAffinityConfig; cfg != nil && cfg.ClientIP != nil && cfg.ClientIP.TimeoutSeconds != nil {
+			timeout = *cfg.ClientIP.TimeoutSeconds
+		}
+		service.SessionAffinity = &localnetv1.Service_ClientIP{
+			ClientIP: &localnetv1.ClientIPAffinity{
+				TimeoutSeconds: timeout,
+			},
+		}
+	}
+
+	// ports information; imports have no target port, the endpoints' ports are matched by name
+	service.Ports = make([]*localnetv1.PortMapping, 0, len(si.Spec.Ports))
+
+	for _, port := range si.Spec.Ports {
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = string(v1.ProtocolTCP)
+		}
+
+		p := &localnetv1.PortMapping{
+			Name:           port.Name,
+			Port:           port.Port,
+			Protocol:       localnetv1.ParseProtocol(protocol),
+			TargetPort:     port.Port,
+			TargetPortName: port.Name,
+		}
+
+		if port.AppProtocol != nil {
+			p.AppProtocol = *port.AppProtocol
+		}
+
+		service.Ports = append(service.Ports, p)
+	}
+
+	h.s.Update(func(tx *proxystore.Tx) {
+		klog.V(3).Info("service import ", service.Namespace, "/", service.Name)
+		tx.SetService(service)
+		// the services set is synced by the services' informer
+	})
+}
+
+func (h *serviceImportEventHandler) OnAdd(obj interface{}) {
+	h.onChange(obj)
+}
+
+func (h *serviceImportEventHandler) OnUpdate(oldObj, newObj interface{}) {
+	h.onChange(newObj)
+}
+
+func (h *serviceImportEventHandler) OnDelete(oldObj interface{}) {
+	si, ok := serviceImportFrom(oldObj)
+	if !ok {
+		return
+	}
+
+	h.s.Update(func(tx *proxystore.Tx) {
+		tx.DelService(si.Namespace, ServiceImportNamePrefix+si.Name)
+	})
+}
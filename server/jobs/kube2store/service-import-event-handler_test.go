@@ -0,0 +1,96 @@
+package kube2store
+
+import (
+	"testing"
+
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+func TestServiceImportEventHandler(t *testing.T) {
+	store := proxystore.New()
+	config := &Config{WithServiceImports: true}
+
+	importHandler := serviceImportEventHandler{
+		eventHandler: eventHandler{s: store, syncSet: true, config: config},
+	}
+	sliceHandler := sliceEventHandler{
+		eventHandler: eventHandler{s: store, syncSet: true, config: config},
+	}
+
+	si := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "multicluster.x-k8s.io/v1alpha1",
+		"kind":       "ServiceImport",
+		"metadata":   map[string]interface{}{"namespace": "default", "name": "web"},
+		"spec": map[string]interface{}{
+			"type": "ClusterSetIP",
+			"ips":  []interface{}{"10.42.0.1"},
+			"ports": []interface{}{
+				map[string]interface{}{"name": "http", "port": int64(80)},
+			},
+			"sessionAffinity": "ClientIP",
+		},
+	}}
+
+	importHandler.OnAdd(si)
+
+	portName, port := "http", int32(8080)
+	sliceHandler.OnAdd(&discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "imported-web-abcde",
+			Labels:    map[string]string{LabelMultiClusterServiceName: "web"},
+		},
+		Endpoints: []discovery.Endpoint{{Addresses: []string{"10.1.2.3"}}},
+		Ports:     []discovery.EndpointPort{{Name: &portName, Port: &port}},
+	})
+
+	name := ServiceImportNamePrefix + "web"
+
+	var service *localnetv1.Service
+	var endpoints []*localnetv1.EndpointInfo
+	store.View(0, func(tx *proxystore.Tx) {
+		tx.Each(proxystore.Services, func(kv *proxystore.KV) bool {
+			if kv.Name == name {
+				service = kv.Service.Service
+			}
+			return true
+		})
+		tx.EachEndpointOfService("default", name, func(ei *localnetv1.EndpointInfo) {
+			endpoints = append(endpoints, ei)
+		})
+	})
+
+	if service == nil {
+		t.Fatal("imported service not found")
+	}
+	if ips := service.IPs.ClusterIPs.V4; len(ips) != 1 || ips[0] != "10.42.0.1" {
+		t.Errorf("expected cluster IP 10.42.0.1, got %v", ips)
+	}
+	if p := service.Ports[0]; p.Protocol != localnetv1.Protocol_TCP || p.Port != 80 {
+		t.Errorf("unexpected port %v", p)
+	}
+	if service.GetClientIP() == nil {
+		t.Error("expected client IP session affinity")
+	}
+
+	if len(endpoints) != 1 {
+		t.Fatalf("expected 1 endpoint, got %d", len(endpoints))
+	}
+	if target := endpoints[0].Endpoint.PortMapping(service.Ports[0]); target != 8080 {
+		t.Errorf("expected target port 8080, got %d", target)
+	}
+
+	importHandler.OnDelete(si)
+
+	store.View(0, func(tx *proxystore.Tx) {
+		tx.Each(proxystore.Services, func(kv *proxystore.KV) bool {
+			t.Errorf("unexpected service after delete: %s/%s", kv.Namespace, kv.Name)
+			return true
+		})
+	})
+}
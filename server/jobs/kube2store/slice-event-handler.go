@@ -30,16 +30,22 @@ const hostNameLabel = "kubernetes.io/hostname"
 
 type sliceEventHandler struct{ eventHandler }
 
-func serviceNameFrom(eps *discovery.EndpointSlice) string {
+func serviceNameFrom(eps *discovery.EndpointSlice, withServiceImports bool) string {
 	if eps.Labels == nil {
 		return ""
 	}
+	if withServiceImports {
+		if name, ok := eps.Labels[LabelMultiClusterServiceName]; ok {
+			// imported endpoints
+			return ServiceImportNamePrefix + name
+		}
+	}
 	return eps.Labels[discovery.LabelServiceName]
 }
 
 func (h sliceEventHandler) OnAdd(obj interface{}) {
 	eps := obj.(*discovery.EndpointSlice)
-	serviceName := serviceNameFrom(eps)
+	serviceName := serviceNameFrom(eps, h.config.WithServiceImports)
 	if serviceName == "" {
 		// no name => not associated with a service => ignore
 		return
@@ -62,6 +68,8 @@ func (h sliceEventHandler) OnAdd(obj interface{}) {
 			info.PodName = t.Name
 		}
 
+		info.Endpoint.Weight = h.endpointWeight(eps.Namespace, info.PodName, eps.Annotations)
+
 		if h := sliceEndpoint.Hostname; h != nil {
 			info.Endpoint.Hostname = *h
 		}
@@ -87,6 +95,15 @@ func (h sliceEventHandler) OnAdd(obj interface{}) {
 		if r := sliceEndpoint.Conditions.Ready; r != nil && *r {
 			info.Conditions.Ready = true
 		}
+		if s := sliceEndpoint.Conditions.Serving; s != nil {
+			info.Conditions.Serving = *s
+		} else {
+			// serving is not set by older clusters, it's the same as ready then
+			info.Conditions.Serving = info.Conditions.Ready
+		}
+		if t := sliceEndpoint.Conditions.Terminating; t != nil && *t {
+			info.Conditions.Terminating = true
+		}
 
 		for _, addr := range sliceEndpoint.Addresses {
 			info.Endpoint.AddAddress(addr)
@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube2store
+
+import (
+	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Informers' transform functions, stripping what the store doesn't use from the objects before
+// they're cached, to reduce the memory used by the informers.
+
+func stripObjectMeta(meta *metav1.ObjectMeta) {
+	meta.ManagedFields = nil
+	meta.OwnerReferences = nil
+	meta.Finalizers = nil
+}
+
+// keepKeys returns the entries of src with the given keys.
+func keepKeys(src map[string]string, keys ...string) (dst map[string]string) {
+	for _, key := range keys {
+		v, ok := src[key]
+		if !ok {
+			continue
+		}
+		if dst == nil {
+			dst = make(map[string]string, len(keys))
+		}
+		dst[key] = v
+	}
+	return
+}
+
+func (j Job) transformService(obj interface{}) (interface{}, error) {
+	if svc, ok := obj.(*v1.Service); ok {
+		stripObjectMeta(&svc.ObjectMeta)
+		svc.Labels = globsFilter(svc.Labels, j.Config.ServiceLabelGlobs)
+		svc.Annotations = globsFilter(svc.Annotations, j.Config.ServiceAnnonationGlobs)
+		svc.Status.Conditions = nil
+	}
+	return obj, nil
+}
+
+func (j Job) transformEndpointSlice(obj interface{}) (interface{}, error) {
+	if eps, ok := obj.(*discovery.EndpointSlice); ok {
+		stripObjectMeta(&eps.ObjectMeta)
+		eps.Labels = keepKeys(eps.Labels, discovery.LabelServiceName, LabelMultiClusterServiceName)
+		eps.Annotations = j.keepWeightAnnotation(eps.Annotations)
+
+		for i := range eps.Endpoints {
+			eps.Endpoints[i].DeprecatedTopology = nil
+		}
+	}
+	return obj, nil
+}
+
+func (j Job) transformEndpoints(obj interface{}) (interface{}, error) {
+	if eps, ok := obj.(*v1.Endpoints); ok {
+		stripObjectMeta(&eps.ObjectMeta)
+		eps.Labels = nil
+		eps.Annotations = j.keepWeightAnnotation(eps.Annotations)
+	}
+	return obj, nil
+}
+
+func (j Job) transformNode(obj interface{}) (interface{}, error) {
+	if node, ok := obj.(*v1.Node); ok {
+		stripObjectMeta(&node.ObjectMeta)
+
+		labels := globsFilter(node.Labels, j.Config.NodeLabelGlobs)
+		if zone, ok := node.Labels[nodeZoneLabel]; ok {
+			if labels == nil {
+				labels = make(map[string]string, 1)
+			}
+			labels[nodeZoneLabel] = zone
+		}
+		node.Labels = labels
+		node.Annotations = globsFilter(node.Annotations, j.Config.NodeAnnotationGlobs)
+
+		node.Spec = v1.NodeSpec{
+			PodCIDR:  node.Spec.PodCIDR,
+			PodCIDRs: node.Spec.PodCIDRs,
+		}
+		node.Status = v1.NodeStatus{
+			Addresses: node.Status.Addresses,
+		}
+	}
+	return obj, nil
+}
+
+func (j Job) transformPod(obj interface{}) (interface{}, error) {
+	if pod, ok := obj.(*v1.Pod); ok {
+		// pods are only used for their weight annotation
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       pod.Namespace,
+				Name:            pod.Name,
+				UID:             pod.UID,
+				ResourceVersion: pod.ResourceVersion,
+				Annotations:     j.keepWeightAnnotation(pod.Annotations),
+			},
+		}, nil
+	}
+	return obj, nil
+}
+
+func (j Job) transformNamespace(obj interface{}) (interface{}, error) {
+	if ns, ok := obj.(*v1.Namespace); ok {
+		// namespaces are only used for their name
+		return &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            ns.Name,
+				UID:             ns.UID,
+				ResourceVersion: ns.ResourceVersion,
+			},
+		}, nil
+	}
+	return obj, nil
+}
+
+func (j Job) transformServiceImport(obj interface{}) (interface{}, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		u.SetManagedFields(nil)
+		u.SetOwnerReferences(nil)
+		u.SetLabels(globsFilter(u.GetLabels(), j.Config.ServiceLabelGlobs))
+		u.SetAnnotations(globsFilter(u.GetAnnotations(), j.Config.ServiceAnnonationGlobs))
+		unstructured.RemoveNestedField(u.Object, "status")
+	}
+	return obj, nil
+}
+
+func (j Job) keepWeightAnnotation(annotations map[string]string) map[string]string {
+	if j.Config.EndpointWeightAnnotation == "" {
+		return nil
+	}
+	return keepKeys(annotations, j.Config.EndpointWeightAnnotation)
+}
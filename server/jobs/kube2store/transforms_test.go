@@ -0,0 +1,76 @@
+package kube2store
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTransforms(t *testing.T) {
+	j := Job{Config: &Config{
+		EndpointWeightAnnotation: "example.com/weight",
+		NodeLabelGlobs:           []string{"kubernetes.io/*"},
+	}}
+
+	meta := func(labels, annotations map[string]string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:          "test",
+			Labels:        labels,
+			Annotations:   annotations,
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "test"}},
+		}
+	}
+
+	obj, _ := j.transformNode(&v1.Node{
+		ObjectMeta: meta(
+			map[string]string{"kubernetes.io/hostname": "n1", nodeZoneLabel: "z1", "other": "x"},
+			map[string]string{"big": "annotation"},
+		),
+		Spec: v1.NodeSpec{PodCIDR: "10.1.0.0/24", ProviderID: "test://n1"},
+		Status: v1.NodeStatus{
+			Addresses: []v1.NodeAddress{{Type: v1.NodeInternalIP, Address: "192.168.0.1"}},
+			Images:    []v1.ContainerImage{{Names: []string{"image"}}},
+		},
+	})
+	node := obj.(*v1.Node)
+
+	if len(node.ManagedFields) != 0 {
+		t.Error("node: managed fields not stripped")
+	}
+	if len(node.Labels) != 2 || node.Labels[nodeZoneLabel] != "z1" || node.Labels["kubernetes.io/hostname"] != "n1" {
+		t.Errorf("node: unexpected labels %v", node.Labels)
+	}
+	if len(node.Annotations) != 0 {
+		t.Errorf("node: unexpected annotations %v", node.Annotations)
+	}
+	if node.Spec.PodCIDR != "10.1.0.0/24" || node.Spec.ProviderID != "" {
+		t.Errorf("node: unexpected spec %v", node.Spec)
+	}
+	if len(node.Status.Addresses) != 1 || len(node.Status.Images) != 0 {
+		t.Errorf("node: unexpected status %v", node.Status)
+	}
+
+	obj, _ = j.transformEndpointSlice(&discovery.EndpointSlice{
+		ObjectMeta: meta(
+			map[string]string{discovery.LabelServiceName: "svc", discovery.LabelManagedBy: "test"},
+			map[string]string{"example.com/weight": "2", "other": "x"},
+		),
+		Endpoints: []discovery.Endpoint{{DeprecatedTopology: map[string]string{"a": "b"}}},
+	})
+	eps := obj.(*discovery.EndpointSlice)
+
+	if len(eps.ManagedFields) != 0 {
+		t.Error("slice: managed fields not stripped")
+	}
+	if len(eps.Labels) != 1 || eps.Labels[discovery.LabelServiceName] != "svc" {
+		t.Errorf("slice: unexpected labels %v", eps.Labels)
+	}
+	if len(eps.Annotations) != 1 || eps.Annotations["example.com/weight"] != "2" {
+		t.Errorf("slice: unexpected annotations %v", eps.Annotations)
+	}
+	if eps.Endpoints[0].DeprecatedTopology != nil {
+		t.Error("slice: deprecated topology not stripped")
+	}
+}
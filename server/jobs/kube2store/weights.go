@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube2store
+
+import (
+	"strconv"
+
+	"k8s.io/klog/v2"
+)
+
+// endpointWeight returns the weight of an endpoint, taken from the configured annotation on its pod
+// or, if the pod doesn't have it, on the source object (EndpointSlice or Endpoints). 0 means no weight.
+func (h eventHandler) endpointWeight(namespace, podName string, sourceAnnotations map[string]string) int32 {
+	annotation := h.config.EndpointWeightAnnotation
+	if annotation == "" {
+		return 0
+	}
+
+	if h.pods != nil && podName != "" {
+		pod, err := h.pods.Pods(namespace).Get(podName)
+		if err == nil {
+			if v, ok := pod.Annotations[annotation]; ok {
+				return parseWeight(v, namespace, podName)
+			}
+		}
+	}
+
+	if v, ok := sourceAnnotations[annotation]; ok {
+		return parseWeight(v, namespace, podName)
+	}
+
+	return 0
+}
+
+// MaxEndpointWeight is the maximum weight of an endpoint (as in IPVS).
+const MaxEndpointWeight = 256
+
+func parseWeight(v, namespace, podName string) int32 {
+	weight, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || weight < 0 {
+		klog.Warningf("ignoring invalid endpoint weight %q (pod %s/%s)", v, namespace, podName)
+		return 0
+	}
+	if weight > MaxEndpointWeight {
+		klog.Warningf("endpoint weight %q too high, using %d (pod %s/%s)", v, MaxEndpointWeight, namespace, podName)
+		return MaxEndpointWeight
+	}
+	return int32(weight)
+}
@@ -0,0 +1,212 @@
+package kube2store
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+func TestSliceEventHandlerWeight(t *testing.T) {
+	const annotation = "example.com/weight"
+
+	store := proxystore.New()
+
+	podIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	podIndexer.Add(&v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default", Name: "heavy", Annotations: map[string]string{annotation: "5"},
+	}})
+	podIndexer.Add(&v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default", Name: "invalid", Annotations: map[string]string{annotation: "-1"},
+	}})
+	podIndexer.Add(&v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default", Name: "huge", Annotations: map[string]string{annotation: "100000"},
+	}})
+
+	handler := sliceEventHandler{
+		eventHandler: eventHandler{
+			s:       store,
+			syncSet: true,
+			config:  &Config{EndpointWeightAnnotation: annotation},
+			pods:    corelisters.NewPodLister(podIndexer),
+		},
+	}
+
+	podEndpoint := func(podName, ip string) discovery.Endpoint {
+		return discovery.Endpoint{
+			Addresses: []string{ip},
+			TargetRef: &v1.ObjectReference{Kind: "Pod", Name: podName},
+		}
+	}
+
+	handler.OnAdd(&discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "test-svc-abcde",
+			Labels:      map[string]string{discovery.LabelServiceName: "test-svc"},
+			Annotations: map[string]string{annotation: "2"},
+		},
+		Endpoints: []discovery.Endpoint{
+			podEndpoint("heavy", "10.0.0.1"),
+			podEndpoint("invalid", "10.0.0.2"),
+			podEndpoint("other", "10.0.0.3"),
+			podEndpoint("huge", "10.0.0.4"),
+		},
+	})
+
+	weights := map[string]int32{}
+	store.View(0, func(tx *proxystore.Tx) {
+		tx.EachEndpointOfService("default", "test-svc", func(ei *localnetv1.EndpointInfo) {
+			weights[ei.PodName] = ei.Endpoint.Weight
+		})
+	})
+
+	for podName, expected := range map[string]int32{"heavy": 5, "invalid": 0, "other": 2, "huge": MaxEndpointWeight} {
+		if weights[podName] != expected {
+			t.Errorf("pod %s: expected weight %d, got %d", podName, expected, weights[podName])
+		}
+	}
+}
+
+func TestPodEventHandlerWeight(t *testing.T) {
+	const annotation = "example.com/weight"
+
+	store := proxystore.New()
+
+	podIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	sliceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{podsIndex: podsIndexFunc})
+
+	sliceHandler := &sliceEventHandler{
+		eventHandler: eventHandler{
+			s:       store,
+			syncSet: true,
+			config:  &Config{EndpointWeightAnnotation: annotation},
+			pods:    corelisters.NewPodLister(podIndexer),
+		},
+	}
+
+	podHandler := &podEventHandler{
+		annotation: annotation,
+		sources:    sliceIndexer,
+		handler:    sliceHandler,
+	}
+
+	slice := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-svc-abcde",
+			Labels:    map[string]string{discovery.LabelServiceName: "test-svc"},
+		},
+		Endpoints: []discovery.Endpoint{{
+			Addresses: []string{"10.0.0.1"},
+			TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web"},
+		}},
+	}
+	sliceIndexer.Add(slice)
+	sliceHandler.OnAdd(slice)
+
+	weight := func() (weight int32) {
+		store.View(0, func(tx *proxystore.Tx) {
+			tx.EachEndpointOfService("default", "test-svc", func(ei *localnetv1.EndpointInfo) {
+				weight = ei.Endpoint.Weight
+			})
+		})
+		return
+	}
+
+	pod := func(weight string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: "web", Annotations: map[string]string{annotation: weight},
+		}}
+	}
+
+	// the pod is received after its slice
+	podIndexer.Add(pod("3"))
+	podHandler.OnAdd(pod("3"))
+	if w := weight(); w != 3 {
+		t.Errorf("expected weight 3 after the pod's add, got %d", w)
+	}
+
+	podIndexer.Update(pod("7"))
+	podHandler.OnUpdate(pod("3"), pod("7"))
+	if w := weight(); w != 7 {
+		t.Errorf("expected weight 7 after the pod's update, got %d", w)
+	}
+
+	podIndexer.Delete(pod("7"))
+	podHandler.OnDelete(pod("7"))
+	if w := weight(); w != 0 {
+		t.Errorf("expected no weight after the pod's delete, got %d", w)
+	}
+}
+
+func TestRemoveNamespaceWithWeightedPods(t *testing.T) {
+	const annotation = "example.com/weight"
+
+	store := proxystore.New()
+
+	podsInformer := cache.NewSharedIndexInformer(&cache.ListWatch{}, &v1.Pod{}, 0, cache.Indexers{})
+	slicesInformer := cache.NewSharedIndexInformer(&cache.ListWatch{}, &discovery.EndpointSlice{}, 0,
+		cache.Indexers{podsIndex: podsIndexFunc})
+
+	sliceHandler := &sliceEventHandler{
+		eventHandler: eventHandler{
+			s:       store,
+			syncSet: true,
+			config:  &Config{EndpointWeightAnnotation: annotation},
+			pods:    corelisters.NewPodLister(podsInformer.GetIndexer()),
+		},
+	}
+
+	slice := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-svc-abcde",
+			Labels:    map[string]string{discovery.LabelServiceName: "test-svc"},
+		},
+		Endpoints: []discovery.Endpoint{{
+			Addresses: []string{"10.0.0.1"},
+			TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "web"},
+		}},
+	}
+
+	podsInformer.GetStore().Add(&v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "default", Name: "web", Annotations: map[string]string{annotation: "3"},
+	}})
+	slicesInformer.GetStore().Add(slice)
+	sliceHandler.OnAdd(slice)
+
+	w := &namespacesWatch{namespaces: map[string]*namespaceWatch{
+		"default": {
+			stopCh: make(chan struct{}),
+			watched: []informerHandler{
+				{slicesInformer, sliceHandler},
+				{podsInformer, &podEventHandler{
+					annotation: annotation,
+					sources:    slicesInformer.GetIndexer(),
+					handler:    sliceHandler,
+				}},
+			},
+		},
+	}}
+
+	w.remove("default")
+
+	count := 0
+	store.View(0, func(tx *proxystore.Tx) {
+		tx.Each(proxystore.Endpoints, func(kv *proxystore.KV) bool {
+			count++
+			return true
+		})
+	})
+
+	if count != 0 {
+		t.Errorf("expected the namespace's endpoints to be removed, %d left", count)
+	}
+}
@@ -24,24 +24,37 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	"sigs.k8s.io/kpng/client/apiconn"
 	"sigs.k8s.io/kpng/client/tlsflags"
+	"sigs.k8s.io/kpng/server/jobs/store2diff"
 	"sigs.k8s.io/kpng/server/pkg/proxystore"
 	"sigs.k8s.io/kpng/server/pkg/server"
+	"sigs.k8s.io/kpng/server/pkg/server/admin"
 	"sigs.k8s.io/kpng/server/pkg/server/endpoints"
 	"sigs.k8s.io/kpng/server/pkg/server/global"
+	"sigs.k8s.io/kpng/server/pkg/server/watches"
 )
 
 type Config struct {
 	BindSpec  string
 	GlobalAPI bool
 	LocalAPI  bool
+	AdminAPI  bool
 	TLS       *tlsflags.Flags
+
+	JournalSize int
+
+	Coalesce store2diff.Coalesce
 }
 
 func (c *Config) BindFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&c.BindSpec, "listen", "tcp://:12090", "serve global API")
 	flags.BoolVar(&c.GlobalAPI, "global-api", true, "serve global API")
 	flags.BoolVar(&c.LocalAPI, "local-api", true, "serve local API")
+	flags.BoolVar(&c.AdminAPI, "admin-api", false, "serve admin API (list the active watches and force their reset)")
+	flags.IntVar(&c.JournalSize, "journal-size", proxystore.DefaultJournalSize, "number of store changes retained to resume watches after reconnections and to update local states incrementally (0 to disable)")
+
+	c.Coalesce.BindFlags(flags)
 
 	if c.TLS == nil {
 		c.TLS = &tlsflags.Flags{}
@@ -53,29 +66,45 @@ func (c *Config) BindFlags(flags *pflag.FlagSet) {
 type Job struct {
 	Store  *proxystore.Store
 	Config *Config
+
+	// Watches tracks the watches and the nodes' apply statuses (watches.DefaultRegistry if nil)
+	Watches *watches.Registry
 }
 
 func (j *Job) Run(ctx context.Context) error {
 	lis := server.MustListen(j.Config.BindSpec)
 
+	j.Store.SetJournalSize(j.Config.JournalSize)
+
 	// setup gRPC server
 	var srv *grpc.Server
 	if tlsCfg := j.Config.TLS.Config(); tlsCfg == nil {
-		srv = grpc.NewServer()
+		srv = grpc.NewServer(apiconn.ServerOptions()...)
 	} else {
 		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
 		tlsCfg.ClientCAs = tlsCfg.RootCAs
 
 		creds := credentials.NewTLS(tlsCfg)
-		srv = grpc.NewServer(grpc.Creds(creds))
+		srv = grpc.NewServer(append(apiconn.ServerOptions(), grpc.Creds(creds))...)
 	}
 
 	// setup server
+	registry := j.Watches
+	if registry == nil {
+		registry = watches.DefaultRegistry
+	}
+
+	// the statuses of the deleted nodes would be kept forever
+	go registry.ForgetRemovedNodes(ctx, j.Store)
+
+	if j.Config.AdminAPI {
+		admin.Setup(srv, j.Store, registry)
+	}
 	if j.Config.GlobalAPI {
-		global.Setup(srv, j.Store)
+		global.Setup(srv, j.Store, registry, &j.Config.Coalesce)
 	}
 	if j.Config.LocalAPI {
-		endpoints.Setup(srv, j.Store)
+		endpoints.Setup(srv, j.Store, registry, &j.Config.Coalesce)
 	}
 
 	// handle exit
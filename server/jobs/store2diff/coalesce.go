@@ -0,0 +1,85 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store2diff
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+// Coalesce batches the store changes sent to a watcher: a change set is sent once the store didn't change
+// for MinInterval, or MaxDelay after its first change if the store keeps changing.
+type Coalesce struct {
+	// MinInterval is the time without changes to wait before sending a change set (coalescing is disabled if 0)
+	MinInterval time.Duration
+	// MaxDelay is the maximum time a change can be delayed (no limit if 0)
+	MaxDelay time.Duration
+}
+
+func (c *Coalesce) BindFlags(flags *pflag.FlagSet) {
+	flags.DurationVar(&c.MinInterval, "sync-min-interval", 0, "time without changes to wait before sending them to a watcher, batching the churn (0 to send them immediately)")
+	flags.DurationVar(&c.MaxDelay, "sync-max-delay", time.Second, "maximum time a change can be delayed by sync-min-interval (0 for no limit)")
+}
+
+// Enabled returns true if the changes are coalesced; c may be nil.
+func (c *Coalesce) Enabled() bool {
+	return c != nil && c.MinInterval > 0
+}
+
+// wait waits for the store changes after rev, and for them to settle if enabled. Returns early if wake
+// receives a value before the store changes (woken is then true), and closed is true if the store is closed.
+func (c *Coalesce) wait(store *proxystore.Store, rev uint64, wake <-chan struct{}) (closed, woken bool) {
+	newRev, closed := store.WaitRevOrWake(rev, 0, wake)
+	if closed || newRev <= rev {
+		return closed, !closed
+	}
+
+	if !c.Enabled() {
+		return
+	}
+
+	rev = newRev
+
+	var deadline time.Time
+	if c.MaxDelay > 0 {
+		deadline = time.Now().Add(c.MaxDelay)
+	}
+
+	for {
+		timeout := c.MinInterval
+		if !deadline.IsZero() {
+			if remaining := time.Until(deadline); remaining < timeout {
+				timeout = remaining
+			}
+		}
+
+		if timeout <= 0 {
+			return
+		}
+
+		// a reset requested now is handled with the next change set
+		newRev, closed = store.WaitRevOrWake(rev, timeout, wake)
+		if closed || newRev == rev {
+			return
+		}
+
+		rev = newRev
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store2diff
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+func TestCoalesce(t *testing.T) {
+	store := proxystore.New()
+
+	// changes every 10ms during 100ms, then nothing
+	go func() {
+		for i := 0; i < 10; i++ {
+			store.Update(func(tx *proxystore.Tx) {
+				tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc" + strconv.Itoa(i)})
+			})
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	c := &Coalesce{MinInterval: 50 * time.Millisecond, MaxDelay: 10 * time.Second}
+
+	start := time.Now()
+	if closed, _ := c.wait(store, 0, nil); closed {
+		t.Fatal("store closed")
+	}
+
+	if elapsed := time.Since(start); elapsed < 120*time.Millisecond {
+		t.Errorf("expected to wait for the changes to settle, waited %v", elapsed)
+	}
+	if rev := store.Rev(); rev != 10 {
+		t.Errorf("expected all the changes to be coalesced, store at rev %d", rev)
+	}
+}
+
+func TestCoalesceMaxDelay(t *testing.T) {
+	store := proxystore.New()
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	// changes every 10ms, forever
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			case <-time.After(10 * time.Millisecond):
+			}
+
+			store.Update(func(tx *proxystore.Tx) {
+				tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc" + strconv.Itoa(i)})
+			})
+		}
+	}()
+
+	c := &Coalesce{MinInterval: 50 * time.Millisecond, MaxDelay: 200 * time.Millisecond}
+
+	start := time.Now()
+	if closed, _ := c.wait(store, 0, nil); closed {
+		t.Fatal("store closed")
+	}
+
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("expected to wait for the max delay, waited %v", elapsed)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store2diff
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+)
+
+// FilteredSink is a Sink requesting only a part of the data.
+type FilteredSink interface {
+	// Filter returns the filter to apply (nil to send everything)
+	Filter() *Filter
+}
+
+// Filter is the compiled form of a localnetv1.WatchFilter. A nil Filter matches everything.
+type Filter struct {
+	namespaces         map[string]bool
+	excludedNamespaces map[string]bool
+	labelSelector      labels.Selector
+	serviceTypes       map[string]bool
+}
+
+// NewFilter compiles a watch filter, returning nil if it's nil.
+func NewFilter(wf *localnetv1.WatchFilter) (f *Filter, err error) {
+	if wf == nil {
+		return
+	}
+
+	f = &Filter{
+		namespaces:         toSet(wf.Namespaces),
+		excludedNamespaces: toSet(wf.ExcludedNamespaces),
+		serviceTypes:       toSet(wf.ServiceTypes),
+	}
+
+	if wf.LabelSelector != "" {
+		f.labelSelector, err = labels.Parse(wf.LabelSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return
+}
+
+func toSet(values []string) (set map[string]bool) {
+	if len(values) == 0 {
+		return nil
+	}
+
+	set = make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return
+}
+
+// MatchNamespace returns true if the given namespace is included by the filter.
+func (f *Filter) MatchNamespace(namespace string) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.namespaces != nil && !f.namespaces[namespace] {
+		return false
+	}
+
+	return !f.excludedNamespaces[namespace]
+}
+
+// MatchService returns true if the given service is included by the filter.
+func (f *Filter) MatchService(svc *localnetv1.Service) bool {
+	if f == nil {
+		return true
+	}
+
+	if !f.MatchNamespace(svc.Namespace) {
+		return false
+	}
+
+	if f.serviceTypes != nil && !f.serviceTypes[svc.Type] {
+		return false
+	}
+
+	if f.labelSelector != nil && !f.labelSelector.Matches(labels.Set(svc.Labels)) {
+		return false
+	}
+
+	return true
+}
+
+// Equal returns true if both filters select the same values.
+func (f *Filter) Equal(other *Filter) bool {
+	if f == nil || other == nil {
+		return f == other
+	}
+
+	return sameSet(f.namespaces, other.namespaces) &&
+		sameSet(f.excludedNamespaces, other.excludedNamespaces) &&
+		sameSet(f.serviceTypes, other.serviceTypes) &&
+		selectorString(f.labelSelector) == selectorString(other.labelSelector)
+}
+
+func sameSet(a, b map[string]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for v := range a {
+		if !b[v] {
+			return false
+		}
+	}
+	return true
+}
+
+func selectorString(selector labels.Selector) string {
+	if selector == nil {
+		return ""
+	}
+	return selector.String()
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store2diff
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+)
+
+func TestFilter(t *testing.T) {
+	svc := &localnetv1.Service{
+		Namespace: "tenant-a",
+		Name:      "web",
+		Type:      "ClusterIP",
+		Labels:    map[string]string{"app": "web"},
+	}
+
+	for _, test := range []struct {
+		filter   *localnetv1.WatchFilter
+		expected bool
+	}{
+		{nil, true},
+		{&localnetv1.WatchFilter{}, true},
+		{&localnetv1.WatchFilter{Namespaces: []string{"tenant-a", "tenant-b"}}, true},
+		{&localnetv1.WatchFilter{Namespaces: []string{"tenant-b"}}, false},
+		{&localnetv1.WatchFilter{ExcludedNamespaces: []string{"tenant-a"}}, false},
+		{&localnetv1.WatchFilter{LabelSelector: "app=web"}, true},
+		{&localnetv1.WatchFilter{LabelSelector: "app!=web"}, false},
+		{&localnetv1.WatchFilter{LabelSelector: "tier"}, false},
+		{&localnetv1.WatchFilter{ServiceTypes: []string{"ClusterIP"}}, true},
+		{&localnetv1.WatchFilter{ServiceTypes: []string{"NodePort", "LoadBalancer"}}, false},
+	} {
+		f, err := NewFilter(test.filter)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", test.filter, err)
+			continue
+		}
+
+		if match := f.MatchService(svc); match != test.expected {
+			t.Errorf("%v: expected match=%v, got %v", test.filter, test.expected, match)
+		}
+	}
+
+	if _, err := NewFilter(&localnetv1.WatchFilter{LabelSelector: "app in (web"}); err == nil {
+		t.Error("invalid label selector should fail")
+	}
+}
+
+func TestFilterEqual(t *testing.T) {
+	filter := func(wf *localnetv1.WatchFilter) *Filter {
+		f, err := NewFilter(wf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	for _, test := range []struct {
+		a, b     *localnetv1.WatchFilter
+		expected bool
+	}{
+		{nil, nil, true},
+		{nil, &localnetv1.WatchFilter{Namespaces: []string{"tenant-a"}}, false},
+		{&localnetv1.WatchFilter{Namespaces: []string{"tenant-a", "tenant-b"}}, &localnetv1.WatchFilter{Namespaces: []string{"tenant-b", "tenant-a"}}, true},
+		{&localnetv1.WatchFilter{Namespaces: []string{"tenant-a"}}, &localnetv1.WatchFilter{ExcludedNamespaces: []string{"tenant-a"}}, false},
+		{&localnetv1.WatchFilter{LabelSelector: "app=web"}, &localnetv1.WatchFilter{LabelSelector: "app = web"}, true},
+		{&localnetv1.WatchFilter{LabelSelector: "app=web"}, &localnetv1.WatchFilter{LabelSelector: "app=db"}, false},
+		{&localnetv1.WatchFilter{ServiceTypes: []string{"NodePort"}}, &localnetv1.WatchFilter{ServiceTypes: []string{"NodePort", "ClusterIP"}}, false},
+	} {
+		if equal := filter(test.a).Equal(filter(test.b)); equal != test.expected {
+			t.Errorf("%v == %v: expected %v, got %v", test.a, test.b, test.expected, equal)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store2diff
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+)
+
+var (
+	watchesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kpng",
+		Subsystem: "watch",
+		Name:      "active",
+		Help:      "Number of active watches",
+	}, []string{"kind"})
+
+	sendDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kpng",
+		Subsystem: "watch",
+		Name:      "send_duration_seconds",
+		Help:      "Duration of the computation and sending of a watch's change sets",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+	}, []string{"kind"})
+
+	diffSize = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "kpng",
+		Subsystem: "watch",
+		Name:      "diff_size",
+		Help:      "Number of set and delete operations in a watch's change sets",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+	}, []string{"kind"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		watchesGauge,
+		sendDuration,
+		diffSize,
+	)
+}
+
+// countingSink counts the set and delete operations sent to the sink
+type countingSink struct {
+	localnetv1.OpSink
+	count int
+}
+
+func (s *countingSink) Send(op *localnetv1.OpItem) error {
+	switch op.Op.(type) {
+	case *localnetv1.OpItem_Set, *localnetv1.OpItem_Delete:
+		s.count++
+	}
+	return s.OpSink.Send(op)
+}
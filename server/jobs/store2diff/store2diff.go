@@ -18,8 +18,12 @@ package store2diff
 
 import (
 	"context"
+	"time"
+
+	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/client/lightdiffstore"
 	"sigs.k8s.io/kpng/server/pkg/proxystore"
 	"sigs.k8s.io/kpng/server/pkg/server/watchstate"
 )
@@ -28,6 +32,12 @@ type Job struct {
 	Store *proxystore.Store
 	Sets  []localnetv1.Set
 	Sink  Sink
+
+	// Kind of the watch (ie: local or global), used in metrics
+	Kind string
+
+	// Coalesce batches the store changes sent to the sink (sent immediately if nil)
+	Coalesce *Coalesce
 }
 
 type Sink interface {
@@ -38,14 +48,70 @@ type Sink interface {
 	SendDiff(w *watchstate.WatchState) (updated bool)
 }
 
+// ResumableSink is a Sink knowing the last revision received by its remote, allowing
+// to send only the changes since that revision.
+type ResumableSink interface {
+	// LastRevision returns the last revision fully received by the remote (nil if unknown)
+	LastRevision() *localnetv1.Revision
+}
+
+// IncrementalSink is a Sink updating the watch state incrementally, instead of setting all the values at
+// each update. It's notified when the watch state's values are all marked as deleted, and must then set
+// all the values on its next update.
+type IncrementalSink interface {
+	WatchStateReset()
+}
+
+// TrackedSink is a Sink informed of the change sets fully sent to its remote, and that can
+// request the whole data set to be sent again.
+type TrackedSink interface {
+	// Synced is called when the change set of the given revision is fully sent
+	Synced(rev *localnetv1.Revision)
+	// ResetRequested returns true (once) when the whole data set must be sent again
+	ResetRequested() bool
+	// ResetNotify returns a channel receiving a value when a reset is requested, to stop waiting for the store
+	ResetNotify() <-chan struct{}
+}
+
 func (j *Job) Run(ctx context.Context) (err error) {
-	w := watchstate.New(j.Sink, j.Sets)
+	kind := j.Kind
+	if kind == "" {
+		kind = "unknown"
+	}
+
+	watchesGauge.WithLabelValues(kind).Inc()
+	defer watchesGauge.WithLabelValues(kind).Dec()
+
+	sink := &countingSink{OpSink: j.Sink}
+	w := watchstate.New(sink, j.Sets)
+
+	tracked, _ := j.Sink.(TrackedSink)
+	filtered, _ := j.Sink.(FilteredSink)
+
+	var resets <-chan struct{}
+	if tracked != nil {
+		resets = tracked.ResetNotify()
+	}
 
 	var (
 		rev    uint64
 		closed bool
+		woken  bool
+		start  time.Time
+		filter *Filter
 	)
 
+	reset := func() {
+		// start over from an empty watch state
+		klog.V(1).Info("reset requested, sending the whole data set")
+		w = watchstate.New(sink, j.Sets)
+		rev = 0
+
+		if incremental, ok := j.Sink.(IncrementalSink); ok {
+			incremental.WatchStateReset()
+		}
+	}
+
 	for {
 		if err = ctx.Err(); err != nil {
 			// check the context is still active; we expect the wtachstate/sink to fail fast in this case
@@ -58,14 +124,50 @@ func (j *Job) Run(ctx context.Context) (err error) {
 			return
 		}
 
+		if tracked != nil && tracked.ResetRequested() {
+			reset()
+		} else if rev == 0 {
+			rev = j.resume(w)
+		}
+
 		if rev == 0 {
 			w.SendReset()
 		}
 
+		// a new filter must be applied without waiting for the store to change
+		viewRev := rev
+		if filtered != nil {
+			if f := filtered.Filter(); !f.Equal(filter) {
+				filter = f
+				viewRev = 0
+			}
+		}
+
+		sink.count = 0
+
 		updated := false
 		for !updated {
+			if viewRev != 0 {
+				closed, woken = j.Coalesce.wait(j.Store, viewRev, resets)
+				if closed {
+					return
+				}
+
+				if woken {
+					if !tracked.ResetRequested() {
+						continue // already handled
+					}
+
+					// the watch was idle, the reset must not wait for the store to change
+					reset()
+					w.SendReset()
+					viewRev = 0
+				}
+			}
+
 			// update the state
-			rev, closed = j.Store.View(rev, func(tx *proxystore.Tx) {
+			rev, closed = j.Store.View(viewRev, func(tx *proxystore.Tx) {
+				start = time.Now()
 				j.Sink.Update(tx, w)
 			})
 
@@ -79,13 +181,55 @@ func (j *Job) Run(ctx context.Context) (err error) {
 
 			// send the diff
 			updated = j.Sink.SendDiff(w)
+			viewRev = rev
 		}
 
 		// signal the change set is fully sent
-		w.SendSync()
+		syncRev := &localnetv1.Revision{Epoch: j.Store.Epoch(), Rev: rev}
+		w.SendSyncRevision(syncRev)
 
 		if w.Err != nil {
 			return w.Err
 		}
+
+		if tracked != nil {
+			tracked.Synced(syncRev)
+		}
+
+		sendDuration.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+		diffSize.WithLabelValues(kind).Observe(float64(sink.count))
 	}
 }
+
+// resume loads the state at the last revision received by the remote, returning that
+// revision if the store could restore it, 0 otherwise.
+func (j *Job) resume(w *watchstate.WatchState) (rev uint64) {
+	resumable, ok := j.Sink.(ResumableSink)
+	if !ok {
+		return
+	}
+
+	lastRev := resumable.LastRevision()
+	if lastRev == nil || lastRev.Rev == 0 {
+		return
+	}
+
+	ok = j.Store.ViewRevision(lastRev.Epoch, lastRev.Rev, func(tx *proxystore.Tx) {
+		j.Sink.Update(tx, w)
+	})
+
+	if !ok {
+		klog.V(1).Info("can't resume from revision ", lastRev.Epoch, ":", lastRev.Rev, ", sending a reset")
+		return
+	}
+
+	// the remote already has this state, so we only need to send the changes from there
+	w.Reset(lightdiffstore.ItemDeleted)
+
+	if incremental, ok := j.Sink.(IncrementalSink); ok {
+		incremental.WatchStateReset()
+	}
+
+	klog.V(1).Info("resuming from revision ", lastRev.Epoch, ":", lastRev.Rev)
+	return lastRev.Rev
+}
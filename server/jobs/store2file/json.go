@@ -0,0 +1,180 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store2file
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	localnetv1 "sigs.k8s.io/kpng/api/localnetv1"
+)
+
+// The states are encoded in JSON with their proto messages in protojson, as encoding/json can't decode
+// their oneofs (ie: the service's SessionAffinity). Using the proto field names, the JSON keys stay the same.
+
+var protoJSONMarshal = protojson.MarshalOptions{UseProtoNames: true}
+
+// protoJSON is a proto message encoded with protojson.
+type protoJSON[T proto.Message] struct {
+	msg T
+}
+
+func (m protoJSON[T]) MarshalJSON() ([]byte, error) {
+	if !m.msg.ProtoReflect().IsValid() {
+		return []byte("null"), nil
+	}
+	return protoJSONMarshal.Marshal(m.msg)
+}
+
+func (m *protoJSON[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	m.msg = m.msg.ProtoReflect().Type().New().Interface().(T)
+	return protojson.Unmarshal(data, m.msg)
+}
+
+func protoJSONs[T proto.Message](msgs []T) (values []protoJSON[T]) {
+	if msgs == nil {
+		return
+	}
+
+	values = make([]protoJSON[T], len(msgs))
+	for i, msg := range msgs {
+		values[i].msg = msg
+	}
+	return
+}
+
+func protoMsgs[T proto.Message](values []protoJSON[T]) (msgs []T) {
+	if values == nil {
+		return
+	}
+
+	msgs = make([]T, len(values))
+	for i, value := range values {
+		msgs[i] = value.msg
+	}
+	return
+}
+
+// unmarshalStrict decodes JSON, rejecting the unknown fields.
+func unmarshalStrict(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+type globalStateJSON struct {
+	Nodes    []protoJSON[*localnetv1.Node]
+	Services []ServiceAndEndpoints
+}
+
+func (s GlobalState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(globalStateJSON{Nodes: protoJSONs(s.Nodes), Services: s.Services})
+}
+
+func (s *GlobalState) UnmarshalJSON(data []byte) error {
+	v := globalStateJSON{}
+	if err := unmarshalStrict(data, &v); err != nil {
+		return err
+	}
+
+	s.Nodes, s.Services = protoMsgs(v.Nodes), v.Services
+	return nil
+}
+
+type serviceAndEndpointsJSON struct {
+	Service   protoJSON[*localnetv1.Service]
+	Endpoints []protoJSON[*localnetv1.EndpointInfo]
+}
+
+func (s ServiceAndEndpoints) MarshalJSON() ([]byte, error) {
+	return json.Marshal(serviceAndEndpointsJSON{
+		Service:   protoJSON[*localnetv1.Service]{s.Service},
+		Endpoints: protoJSONs(s.Endpoints),
+	})
+}
+
+func (s *ServiceAndEndpoints) UnmarshalJSON(data []byte) error {
+	v := serviceAndEndpointsJSON{}
+	if err := unmarshalStrict(data, &v); err != nil {
+		return err
+	}
+
+	s.Service, s.Endpoints = v.Service.msg, protoMsgs(v.Endpoints)
+	return nil
+}
+
+type nodeStateJSON struct {
+	Node     protoJSON[*localnetv1.Node]
+	Services []NodeServiceAndEndpoints
+}
+
+func (s NodeState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nodeStateJSON{Node: protoJSON[*localnetv1.Node]{s.Node}, Services: s.Services})
+}
+
+func (s *NodeState) UnmarshalJSON(data []byte) error {
+	v := nodeStateJSON{}
+	if err := unmarshalStrict(data, &v); err != nil {
+		return err
+	}
+
+	s.Node, s.Services = v.Node.msg, v.Services
+	return nil
+}
+
+type nodeServiceAndEndpointsJSON struct {
+	Service   protoJSON[*localnetv1.Service]
+	Endpoints map[string]protoJSON[*localnetv1.Endpoint]
+}
+
+func (s NodeServiceAndEndpoints) MarshalJSON() ([]byte, error) {
+	v := nodeServiceAndEndpointsJSON{Service: protoJSON[*localnetv1.Service]{s.Service}}
+
+	if s.Endpoints != nil {
+		v.Endpoints = make(map[string]protoJSON[*localnetv1.Endpoint], len(s.Endpoints))
+		for key, ep := range s.Endpoints {
+			v.Endpoints[key] = protoJSON[*localnetv1.Endpoint]{ep}
+		}
+	}
+
+	return json.Marshal(v)
+}
+
+func (s *NodeServiceAndEndpoints) UnmarshalJSON(data []byte) error {
+	v := nodeServiceAndEndpointsJSON{}
+	if err := unmarshalStrict(data, &v); err != nil {
+		return err
+	}
+
+	s.Service = v.Service.msg
+
+	if v.Endpoints != nil {
+		s.Endpoints = make(map[string]*localnetv1.Endpoint, len(v.Endpoints))
+		for key, ep := range v.Endpoints {
+			s.Endpoints[key] = ep.msg
+		}
+	}
+
+	return nil
+}
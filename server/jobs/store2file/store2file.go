@@ -18,23 +18,64 @@ package store2file
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/proto"
 	"gopkg.in/yaml.v2"
 
 	"k8s.io/klog/v2"
 
 	localnetv1 "sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/pkg/endpoints"
 	proxystore "sigs.k8s.io/kpng/server/pkg/proxystore"
+	"sigs.k8s.io/kpng/server/serde"
 )
 
 type Config struct {
-	FilePath string
+	FilePath  string
+	Format    string
+	NodeNames []string
 }
 
 func (c *Config) BindFlags(flags *pflag.FlagSet) {
 	flags.StringVarP(&c.FilePath, "output", "o", "global-state.yaml", "Output file for the global state")
+	flags.StringVar(&c.Format, "format", "", "Output format: yaml, json or proto (from the output file's extension if not set, yaml by default)")
+	flags.StringSliceVar(&c.NodeNames, "node-views", nil, "Also write the local view of these nodes, next to the output file (as <output>.<node name>.<ext>)")
+}
+
+// OutputFormat returns the format of the output, guessing it from the file's extension if not set.
+func (c *Config) OutputFormat() (string, error) {
+	format := c.Format
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(c.FilePath)) {
+		case ".json":
+			format = "json"
+		case ".pb":
+			format = "proto"
+		default:
+			format = "yaml"
+		}
+	}
+
+	switch format {
+	case "yaml", "json", "proto":
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown output format: %q", format)
+	}
+}
+
+// NodeFilePath returns the path of the file receiving the local view of the given node.
+func (c *Config) NodeFilePath(nodeName string) string {
+	ext := filepath.Ext(c.FilePath)
+	return strings.TrimSuffix(c.FilePath, ext) + "." + nodeName + ext
 }
 
 type Job struct {
@@ -42,64 +83,138 @@ type Job struct {
 	Config *Config
 }
 
+// output is a value to write to a file
+type output struct {
+	path  string
+	value interface{}
+}
+
 func (j *Job) Run(ctx context.Context) (err error) {
+	format, err := j.Config.OutputFormat()
+	if err != nil {
+		return
+	}
+
 	var (
 		rev    uint64
 		closed = false
 	)
 
 	for !closed {
-		state := GlobalState{}
-		ok := false
+		var outputs []output
 
 		rev, closed = j.Store.View(rev, func(tx *proxystore.Tx) {
 			if !tx.AllSynced() {
 				return
 			}
 
-			tx.Each(proxystore.Nodes, func(kv *proxystore.KV) bool {
-				state.Nodes = append(state.Nodes, kv.Node.Node)
-				return true
-			})
+			if format == "proto" {
+				var snapshot *localnetv1.StoreSnapshot
+				snapshot, err = tx.Snapshot()
+				outputs = append(outputs, output{j.Config.FilePath, snapshot})
+			} else {
+				outputs = append(outputs, output{j.Config.FilePath, globalState(tx)})
+			}
 
-			tx.Each(proxystore.Services, func(kv *proxystore.KV) bool {
-				sae := ServiceAndEndpoints{
-					Service: kv.Service.Service,
-				}
+			for _, nodeName := range j.Config.NodeNames {
+				state := NodeView(tx, nodeName)
 
-				tx.EachEndpointOfService(kv.Namespace, kv.Name, func(ep *localnetv1.EndpointInfo) {
-					sae.Endpoints = append(sae.Endpoints, ep)
-				})
+				var value interface{} = state
+				if format == "proto" {
+					value, err = state.Snapshot()
+				}
 
-				state.Services = append(state.Services, sae)
+				outputs = append(outputs, output{j.Config.NodeFilePath(nodeName), value})
+			}
+		})
 
-				return true
-			})
+		if err != nil {
+			return
+		}
 
-			ok = true
-		})
+		// write the outputs
+		for _, out := range outputs {
+			err = writeFile(out.path, func(w io.Writer) error { return encode(w, format, out.value) })
+			if err != nil {
+				return
+			}
+		}
 
-		if !ok {
-			continue
+		if len(outputs) != 0 {
+			klog.Info("wrote global state")
 		}
+	}
 
-		// write the output
-		var out *os.File
-		out, err = os.Create(j.Config.FilePath)
-		if err != nil {
-			return
+	return
+}
+
+func globalState(tx *proxystore.Tx) (state GlobalState) {
+	tx.Each(proxystore.Nodes, func(kv *proxystore.KV) bool {
+		state.Nodes = append(state.Nodes, kv.Node.Node)
+		return true
+	})
+
+	tx.Each(proxystore.Services, func(kv *proxystore.KV) bool {
+		sae := ServiceAndEndpoints{
+			Service: kv.Service.Service,
 		}
 
-		err = yaml.NewEncoder(out).Encode(state)
-		out.Close()
+		tx.EachEndpointOfService(kv.Namespace, kv.Name, func(ep *localnetv1.EndpointInfo) {
+			sae.Endpoints = append(sae.Endpoints, ep)
+		})
+
+		state.Services = append(state.Services, sae)
+
+		return true
+	})
+
+	return
+}
+
+func encode(w io.Writer, format string, value interface{}) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(value)
+
+	case "proto":
+		ba, err := proto.Marshal(value.(proto.Message))
 		if err != nil {
-			return
+			return err
 		}
+		_, err = w.Write(ba)
+		return err
 
-		klog.Info("wrote global state")
+	default:
+		return yaml.NewEncoder(w).Encode(value)
 	}
+}
 
-	return
+// writeFile replaces the file atomically, so readers never see a partial file
+func writeFile(path string, write func(w io.Writer) error) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return
+	}
+
+	defer os.Remove(tmp.Name()) // no-op after the rename
+
+	if err = write(tmp); err != nil {
+		tmp.Close()
+		return
+	}
+
+	if err = tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return
+	}
+
+	if err = tmp.Close(); err != nil {
+		return
+	}
+
+	return os.Rename(tmp.Name(), path)
 }
 
 type GlobalState struct {
@@ -111,3 +226,85 @@ type ServiceAndEndpoints struct {
 	Service   *localnetv1.Service
 	Endpoints []*localnetv1.EndpointInfo
 }
+
+// NodeState is the local view of a node, as sent by the local API.
+type NodeState struct {
+	Node     *localnetv1.Node
+	Services []NodeServiceAndEndpoints
+}
+
+type NodeServiceAndEndpoints struct {
+	Service *localnetv1.Service
+	// Endpoints of the service for the node, flagged with their eligibility for internal and/or external traffic,
+	// by their key in the local API (see endpoints.LocalKey)
+	Endpoints map[string]*localnetv1.Endpoint
+}
+
+// NodeView returns the local view of the given node (see endpoints.LocalForNode).
+func NodeView(tx *proxystore.Tx, nodeName string) (state *NodeState) {
+	state = &NodeState{Node: tx.GetNode(nodeName)}
+
+	tx.Each(proxystore.Services, func(kv *proxystore.KV) bool {
+		svc := kv.Service.Service
+		key := []byte(svc.Namespace + "/" + svc.Name)
+
+		infos := endpoints.LocalForNode(tx, kv.Service, nodeName)
+
+		seps := NodeServiceAndEndpoints{
+			Service:   svc,
+			Endpoints: make(map[string]*localnetv1.Endpoint, len(infos)),
+		}
+
+		for _, ei := range infos {
+			epKey := endpoints.LocalKey(key, ei.PodName, serde.Hash(ei.Endpoint))
+			seps.Endpoints[string(epKey)] = ei.Endpoint
+		}
+
+		state.Services = append(state.Services, seps)
+		return true
+	})
+
+	return
+}
+
+// Snapshot returns the node's view in the local API's sets, keyed as a local sink would see them.
+func (state *NodeState) Snapshot() (snapshot *localnetv1.StoreSnapshot, err error) {
+	snapshot = &localnetv1.StoreSnapshot{
+		SyncedSets: []localnetv1.Set{localnetv1.Set_ServicesSet, localnetv1.Set_EndpointsSet, localnetv1.Set_NodesSet},
+	}
+
+	add := func(set localnetv1.Set, path string, m proto.Message) {
+		if err != nil {
+			return
+		}
+
+		var ba []byte
+		ba, err = proto.Marshal(m)
+
+		snapshot.Values = append(snapshot.Values, &localnetv1.Value{
+			Ref:   &localnetv1.Ref{Set: set, Path: path},
+			Bytes: ba,
+		})
+	}
+
+	if state.Node != nil {
+		add(localnetv1.Set_NodesSet, state.Node.Name, state.Node)
+	}
+
+	for _, seps := range state.Services {
+		key := seps.Service.Namespace + "/" + seps.Service.Name
+		add(localnetv1.Set_ServicesSet, key, seps.Service)
+
+		epKeys := make([]string, 0, len(seps.Endpoints))
+		for epKey := range seps.Endpoints {
+			epKeys = append(epKeys, epKey)
+		}
+		sort.Strings(epKeys)
+
+		for _, epKey := range epKeys {
+			add(localnetv1.Set_EndpointsSet, epKey, seps.Endpoints[epKey])
+		}
+	}
+
+	return
+}
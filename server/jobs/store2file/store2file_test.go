@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store2file
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v2"
+
+	localnetv1 "sigs.k8s.io/kpng/api/localnetv1"
+	proxystore "sigs.k8s.io/kpng/server/pkg/proxystore"
+	"sigs.k8s.io/kpng/server/serde"
+)
+
+func TestGlobalStateRoundTrip(t *testing.T) {
+	svc := &localnetv1.Service{
+		Namespace:      "default",
+		Name:           "dual",
+		Type:           "ClusterIP",
+		IPs:            &localnetv1.ServiceIPs{ClusterIPs: localnetv1.NewIPSet("10.1.0.1", "fd00::1")},
+		IPFamilyPolicy: "RequireDualStack",
+		IPFamilies:     []string{"IPv4", "IPv6"},
+		Ports: []*localnetv1.PortMapping{
+			{Name: "http", Protocol: localnetv1.Protocol_TCP, Port: 80, TargetPort: 8080, AppProtocol: "http"},
+		},
+	}
+
+	out, err := yaml.Marshal(GlobalState{Services: []ServiceAndEndpoints{{Service: svc}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := GlobalState{}
+	if err = yaml.UnmarshalStrict(out, &state); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(state.Services) != 1 || !proto.Equal(svc, state.Services[0].Service) {
+		t.Errorf("round-trip failed:\n%s", string(out))
+	}
+}
+
+// testStore returns a synced store with a service whose endpoints are on node-a (pod-a) and node-b (no pod).
+func testStore() *proxystore.Store {
+	store := proxystore.New()
+
+	endpoint := func(ip, nodeName, podName string) *localnetv1.EndpointInfo {
+		return &localnetv1.EndpointInfo{
+			Namespace:   "default",
+			SourceName:  "web",
+			ServiceName: "web",
+			PodName:     podName,
+			Topology:    &localnetv1.TopologyInfo{Node: nodeName},
+			Conditions:  &localnetv1.EndpointConditions{Ready: true},
+			Endpoint:    &localnetv1.Endpoint{IPs: localnetv1.NewIPSet(ip)},
+		}
+	}
+
+	store.Update(func(tx *proxystore.Tx) {
+		tx.SetNode(&localnetv1.Node{Name: "node-a", Topology: &localnetv1.TopologyInfo{Node: "node-a"}})
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "web", Type: "ClusterIP"})
+		tx.SetEndpointsOfSource("default", "web", []*localnetv1.EndpointInfo{
+			endpoint("10.2.0.1", "node-a", "pod-a"),
+			endpoint("10.2.0.2", "node-b", ""),
+		})
+
+		for _, set := range proxystore.AllSets {
+			tx.SetSync(set)
+		}
+	})
+
+	return store
+}
+
+func TestJobNodeViews(t *testing.T) {
+	store := testStore()
+
+	cfg := &Config{
+		FilePath:  filepath.Join(t.TempDir(), "state.json"),
+		NodeNames: []string{"node-a"},
+	}
+
+	go (&Job{Store: store, Config: cfg}).Run(context.Background())
+	defer store.Close()
+
+	var ba []byte
+	for i := 0; ; i++ {
+		var err error
+		if ba, err = os.ReadFile(cfg.NodeFilePath("node-a")); err == nil {
+			break
+		}
+		if i == 100 {
+			t.Fatal("node view not written: ", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	view := NodeState{}
+	if err := json.Unmarshal(ba, &view); err != nil {
+		t.Fatal(err)
+	}
+
+	if view.Node.GetName() != "node-a" || len(view.Services) != 1 || len(view.Services[0].Endpoints) != 2 {
+		t.Fatalf("unexpected node view:\n%s", string(ba))
+	}
+
+	local := 0
+	for _, ep := range view.Services[0].Endpoints {
+		if ep.Local {
+			local++
+		}
+		if !ep.IsInternal() || !ep.IsExternal() {
+			t.Errorf("endpoint should be eligible for all traffic: %v", ep)
+		}
+	}
+	if local != 1 {
+		t.Errorf("expected 1 local endpoint, got %d", local)
+	}
+
+	if _, err := os.Stat(cfg.FilePath); err != nil {
+		t.Error("global state not written: ", err)
+	}
+}
+
+func TestNodeStateSnapshot(t *testing.T) {
+	store := testStore()
+
+	var state *NodeState
+	store.View(0, func(tx *proxystore.Tx) {
+		state = NodeView(tx, "node-a")
+	})
+
+	snapshot, err := state.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// keyed as the local API does: by pod name, or by hash if there's none
+	var remote *localnetv1.Endpoint
+	for _, ep := range state.Services[0].Endpoints {
+		if !ep.Local {
+			remote = ep
+		}
+	}
+	hashKey := "default/web/" + strconv.FormatUint(serde.Hash(remote), 16)
+
+	paths := []string{}
+	for _, v := range snapshot.Values {
+		paths = append(paths, v.Ref.Set.String()+" "+v.Ref.Path)
+	}
+
+	expected := []string{"NodesSet node-a", "ServicesSet default/web", "EndpointsSet " + hashKey, "EndpointsSet default/web/pod-a"}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, paths)
+	}
+}
@@ -32,9 +32,18 @@ type Sink interface {
 	localnetv1.OpSink
 }
 
+// SetsSelector is a Sink selecting the sets to send.
+type SetsSelector interface {
+	// Sets returns the sets to send (all if empty)
+	Sets() []localnetv1.Set
+}
+
 type Job struct {
 	Store *proxystore.Store
 	Sink  Sink
+
+	// Coalesce batches the store changes sent to the sink (sent immediately if nil)
+	Coalesce *store2diff.Coalesce
 }
 
 var sets = []localnetv1.Set{
@@ -48,6 +57,9 @@ func (j *Job) Run(ctx context.Context) error {
 		Store: j.Store,
 		Sets:  sets,
 		Sink:  j,
+		Kind:  "global",
+
+		Coalesce: j.Coalesce,
 	}
 
 	return job.Run(ctx)
@@ -57,6 +69,60 @@ func (j *Job) Wait() (err error) {
 	return j.Sink.Wait()
 }
 
+// Filter returns the filter of the sink, if any.
+func (j *Job) Filter() *store2diff.Filter {
+	if filtered, ok := j.Sink.(store2diff.FilteredSink); ok {
+		return filtered.Filter()
+	}
+	return nil
+}
+
+func (j *Job) selectedSets() (selected map[localnetv1.Set]bool) {
+	selected = make(map[localnetv1.Set]bool, len(sets))
+
+	if selector, ok := j.Sink.(SetsSelector); ok {
+		for _, set := range selector.Sets() {
+			selected[set] = true
+		}
+	}
+
+	if len(selected) == 0 {
+		// no selection => all sets
+		for _, set := range sets {
+			selected[set] = true
+		}
+	}
+
+	return
+}
+
+func (j *Job) LastRevision() *localnetv1.Revision {
+	if resumable, ok := j.Sink.(store2diff.ResumableSink); ok {
+		return resumable.LastRevision()
+	}
+	return nil
+}
+
+func (j *Job) Synced(rev *localnetv1.Revision) {
+	if tracked, ok := j.Sink.(store2diff.TrackedSink); ok {
+		tracked.Synced(rev)
+	}
+}
+
+func (j *Job) ResetRequested() bool {
+	if tracked, ok := j.Sink.(store2diff.TrackedSink); ok {
+		return tracked.ResetRequested()
+	}
+	return false
+}
+
+func (j *Job) ResetNotify() <-chan struct{} {
+	if tracked, ok := j.Sink.(store2diff.TrackedSink); ok {
+		return tracked.ResetNotify()
+	}
+	return nil
+}
+
 func (j *Job) Update(tx *proxystore.Tx, w *watchstate.WatchState) {
 	if !tx.AllSynced() {
 		return
@@ -65,10 +131,38 @@ func (j *Job) Update(tx *proxystore.Tx, w *watchstate.WatchState) {
 	_, task := trace.NewTask(context.Background(), "GlobalState.Update")
 	defer task.End()
 
+	filter := j.Filter()
+	selected := j.selectedSets()
+
+	// services matching the filter (only needed to filter endpoints)
+	var services map[string]bool
+	if filter != nil && selected[localnetv1.Set_GlobalEndpointInfos] {
+		services = map[string]bool{}
+		tx.Each(proxystore.Services, func(kv *proxystore.KV) bool {
+			if filter.MatchService(kv.Service.Service) {
+				services[kv.Namespace+"/"+kv.Name] = true
+			}
+			return true
+		})
+	}
+
 	// sync all stores
 	for _, set := range sets {
+		if !selected[set] {
+			continue
+		}
+
 		diff := w.StoreFor(set)
 		tx.Each(set, func(kv *proxystore.KV) bool {
+			if filter != nil {
+				if kv.Service != nil && !filter.MatchService(kv.Service.Service) {
+					return true
+				}
+				if kv.Endpoint != nil && !services[kv.Namespace+"/"+kv.Endpoint.ServiceName] {
+					return true
+				}
+			}
+
 			h := kv.Value.GetHash()
 			diff.Set([]byte(kv.Path()), h, kv.Value)
 			return true
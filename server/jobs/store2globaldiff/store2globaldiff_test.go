@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store2globaldiff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/jobs/store2diff"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+var errDone = errors.New("done")
+
+// testSink prints the received ops, stopping after the given number of change sets
+type testSink struct {
+	lastRev *localnetv1.Revision
+	filter  *store2diff.Filter
+	sets    []localnetv1.Set
+	waits   int
+}
+
+func (s *testSink) Filter() *store2diff.Filter { return s.filter }
+func (s *testSink) Sets() []localnetv1.Set     { return s.sets }
+
+func (s *testSink) Wait() error {
+	if s.waits == 0 {
+		return errDone
+	}
+	s.waits--
+	return nil
+}
+
+func (s *testSink) LastRevision() *localnetv1.Revision { return s.lastRev }
+
+func (s *testSink) Send(op *localnetv1.OpItem) error {
+	switch v := op.Op.(type) {
+	case *localnetv1.OpItem_Reset_:
+		fmt.Println("reset")
+	case *localnetv1.OpItem_Set:
+		fmt.Println("set", v.Set.Ref.Path)
+	case *localnetv1.OpItem_Delete:
+		fmt.Println("delete", v.Delete.Path)
+	case *localnetv1.OpItem_Sync:
+		fmt.Println("sync")
+		s.lastRev = op.Revision
+	}
+	return nil
+}
+
+func ExampleJob_resume() {
+	store := proxystore.New()
+
+	store.Update(func(tx *proxystore.Tx) {
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc0"})
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc1"})
+		for _, set := range proxystore.AllSets {
+			tx.SetSync(set)
+		}
+	})
+
+	sink := &testSink{waits: 1}
+
+	fmt.Println("first connection:")
+	(&Job{Store: store, Sink: sink}).Run(context.Background())
+
+	store.Update(func(tx *proxystore.Tx) {
+		tx.DelService("default", "svc0")
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc2"})
+	})
+
+	sink.waits = 1
+
+	fmt.Println("resumed connection:")
+	(&Job{Store: store, Sink: sink}).Run(context.Background())
+
+	sink.waits = 1
+	sink.lastRev = &localnetv1.Revision{Epoch: store.Epoch() + 1, Rev: 1}
+
+	fmt.Println("connection from another epoch:")
+	(&Job{Store: store, Sink: sink}).Run(context.Background())
+
+	// Output:
+	// first connection:
+	// reset
+	// set default|svc0||
+	// set default|svc1||
+	// sync
+	// resumed connection:
+	// set default|svc2||
+	// delete default|svc0||
+	// sync
+	// connection from another epoch:
+	// reset
+	// set default|svc1||
+	// set default|svc2||
+	// sync
+}
+
+func ExampleJob_filter() {
+	store := proxystore.New()
+
+	store.Update(func(tx *proxystore.Tx) {
+		tx.SetNode(&localnetv1.Node{Name: "node-a"})
+		tx.SetService(&localnetv1.Service{Namespace: "tenant-a", Name: "svc0"})
+		tx.SetService(&localnetv1.Service{Namespace: "tenant-b", Name: "svc1"})
+		tx.SetEndpointsOfSource("tenant-a", "svc0", []*localnetv1.EndpointInfo{{
+			Namespace: "tenant-a", SourceName: "svc0", ServiceName: "svc0",
+			Endpoint: &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.1.0.1")},
+		}})
+		tx.SetEndpointsOfSource("tenant-b", "svc1", []*localnetv1.EndpointInfo{{
+			Namespace: "tenant-b", SourceName: "svc1", ServiceName: "svc1",
+			Endpoint: &localnetv1.Endpoint{IPs: localnetv1.NewIPSet("10.1.0.2")},
+		}})
+		for _, set := range proxystore.AllSets {
+			tx.SetSync(set)
+		}
+	})
+
+	filter, _ := store2diff.NewFilter(&localnetv1.WatchFilter{Namespaces: []string{"tenant-a"}})
+
+	sink := &testSink{
+		waits:  1,
+		filter: filter,
+		sets:   []localnetv1.Set{localnetv1.Set_GlobalServiceInfos, localnetv1.Set_GlobalEndpointInfos},
+	}
+
+	(&Job{Store: store, Sink: sink}).Run(context.Background())
+
+	// Output:
+	// reset
+	// set tenant-a|svc0||
+	// set tenant-a|svc0|svc0|a41cccb91e8f5d16
+	// set tenant-a||svc0|a41cccb91e8f5d16
+	// sync
+}
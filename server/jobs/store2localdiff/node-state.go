@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store2localdiff
+
+import (
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/pkg/endpoints"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+	"sigs.k8s.io/kpng/server/serde"
+)
+
+// maxChanges is the number of service changes kept to update the watchers incrementally
+const maxChanges = 10000
+
+// NodeStates shares the local states computed for each node between the watchers of that node.
+type NodeStates struct {
+	mu     sync.Mutex
+	states map[string]*nodeState
+}
+
+func NewNodeStates() *NodeStates {
+	return &NodeStates{states: map[string]*nodeState{}}
+}
+
+// acquire returns the state of the node, creating it if needed. It must be released after use.
+func (ns *NodeStates) acquire(nodeName string) *nodeState {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	st, ok := ns.states[nodeName]
+	if !ok {
+		st = newNodeState(nodeName)
+		ns.states[nodeName] = st
+	}
+
+	st.refs++
+	return st
+}
+
+// release forgets the state of the node when it's not used anymore.
+func (ns *NodeStates) release(st *nodeState) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	st.refs--
+	if st.refs == 0 {
+		delete(ns.states, st.nodeName)
+	}
+}
+
+// nodeState is the local state of a node, updated incrementally from the store's changes.
+type nodeState struct {
+	sync.Mutex
+
+	nodeName string
+	refs     int
+
+	// rev is the store revision of this state
+	rev uint64
+
+	node     *localnetv1.Node
+	nodeHash uint64
+	// nodeRev is the revision of the last change of the node
+	nodeRev uint64
+
+	services map[string]*serviceState
+
+	// changes of services, in revision order; they're complete after changesFrom
+	changes     []serviceChange
+	changesFrom uint64
+}
+
+type serviceState struct {
+	service   *localnetv1.Service
+	hash      uint64
+	endpoints []endpointState
+}
+
+type endpointState struct {
+	// anonymous is true for endpoints without a pod name
+	anonymous bool
+	key       []byte
+	hash      uint64
+	endpoint  *localnetv1.Endpoint
+}
+
+type serviceChange struct {
+	rev uint64
+	key string
+}
+
+func newNodeState(nodeName string) *nodeState {
+	return &nodeState{
+		nodeName: nodeName,
+		services: map[string]*serviceState{},
+	}
+}
+
+// update brings the state to the transaction's revision, only computing the services changed since
+// the state's revision when possible.
+func (st *nodeState) update(tx *proxystore.Tx) {
+	rev := tx.Rev()
+	if st.rev != 0 && st.rev == rev {
+		return
+	}
+
+	// the requesting node's record
+	node := tx.GetNode(st.nodeName)
+	nodeHash := uint64(0)
+	if node != nil {
+		nodeHash = serde.Hash(node)
+	}
+
+	nodeChanged := st.rev == 0 || nodeHash != st.nodeHash
+	if nodeChanged {
+		st.node, st.nodeHash, st.nodeRev = node, nodeHash, rev
+	}
+
+	affected := map[string]bool{}
+	full := nodeChanged // the node's topology may change the endpoints of every service
+
+	if !full {
+		full = !tx.ChangesSince(st.rev, func(kv *proxystore.KV) {
+			switch kv.Set {
+			case proxystore.Services:
+				affected[kv.Namespace+"/"+kv.Name] = true
+			case proxystore.Endpoints:
+				if kv.Name != "" { // the entries indexed by source only have a twin indexed by service
+					affected[kv.Namespace+"/"+kv.Name] = true
+				}
+			}
+		})
+	}
+
+	st.rev = rev
+
+	if full {
+		st.services = make(map[string]*serviceState, len(st.services))
+		tx.Each(proxystore.Services, func(kv *proxystore.KV) bool {
+			st.services[kv.Namespace+"/"+kv.Name] = st.computeService(tx, kv.Service)
+			return true
+		})
+
+		st.changes = nil
+		st.changesFrom = rev
+		return
+	}
+
+	for key := range affected {
+		namespace, name, _ := strings.Cut(key, "/")
+
+		if si := tx.GetService(namespace, name); si == nil {
+			delete(st.services, key)
+		} else {
+			st.services[key] = st.computeService(tx, si)
+		}
+
+		st.changes = append(st.changes, serviceChange{rev: rev, key: key})
+	}
+
+	// forget the oldest changes (by whole revisions)
+	for len(st.changes) > maxChanges {
+		oldest := st.changes[0].rev
+		for len(st.changes) != 0 && st.changes[0].rev == oldest {
+			st.changes = st.changes[1:]
+		}
+		st.changesFrom = oldest
+	}
+}
+
+func (st *nodeState) computeService(tx *proxystore.Tx, si *localnetv1.ServiceInfo) (ss *serviceState) {
+	svc := si.Service
+	key := []byte(svc.Namespace + "/" + svc.Name)
+
+	ss = &serviceState{
+		service: svc,
+		hash:    si.Hash,
+	}
+
+	// filter endpoints for this node
+	endpointInfos := endpoints.LocalForNode(tx, si, st.nodeName)
+
+	ss.endpoints = make([]endpointState, 0, len(endpointInfos))
+
+	for _, ei := range endpointInfos {
+		// hash only the endpoint
+		hash := serde.Hash(ei.Endpoint)
+
+		ss.endpoints = append(ss.endpoints, endpointState{
+			anonymous: ei.PodName == "",
+			key:       endpoints.LocalKey(key, ei.PodName, hash),
+			hash:      hash,
+			endpoint:  ei.Endpoint,
+		})
+	}
+
+	return
+}
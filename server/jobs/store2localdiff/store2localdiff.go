@@ -19,27 +19,37 @@ package store2localdiff
 import (
 	"context"
 	"runtime/trace"
-	"strconv"
 
 	"sigs.k8s.io/kpng/api/localnetv1"
 	"sigs.k8s.io/kpng/client/lightdiffstore"
 	"sigs.k8s.io/kpng/client/localsink"
 	"sigs.k8s.io/kpng/server/jobs/store2diff"
-	"sigs.k8s.io/kpng/server/pkg/endpoints"
 	"sigs.k8s.io/kpng/server/pkg/proxystore"
 	"sigs.k8s.io/kpng/server/pkg/server/watchstate"
-	"sigs.k8s.io/kpng/server/serde"
 )
 
 type Job struct {
 	Store *proxystore.Store
 	Sink  localsink.Sink
+
+	// States shares the computed local states between the jobs of the same store (a private one is used if nil)
+	States *NodeStates
+
+	// Coalesce batches the store changes sent to the sink (sent immediately if nil)
+	Coalesce *store2diff.Coalesce
 }
 
 func (j *Job) Run(ctx context.Context) error {
+	states := j.States
+	if states == nil {
+		states = NewNodeStates()
+	}
+
 	run := &jobRun{
-		Sink: j.Sink,
+		Sink:   j.Sink,
+		states: states,
 	}
+	defer run.release()
 
 	job := &store2diff.Job{
 		Store: j.Store,
@@ -47,8 +57,12 @@ func (j *Job) Run(ctx context.Context) error {
 			localnetv1.Set_ServicesSet,
 			localnetv1.Set_EndpointsSet,
 			localnetv1.Set_EndpointsSet, // 2nd endpoints set for endpoints which do not have a corresponding pod name
+			localnetv1.Set_NodesSet,
 		},
 		Sink: run,
+		Kind: "local",
+
+		Coalesce: j.Coalesce,
 	}
 
 	j.Sink.Setup()
@@ -59,69 +73,192 @@ func (j *Job) Run(ctx context.Context) error {
 type jobRun struct {
 	localsink.Sink
 	nodeName string
+
+	states *NodeStates
+	state  *nodeState
+
+	// wRev is the revision of the state in the watch state (0 if a full update is needed)
+	wRev uint64
+	// wDeleted is true when the watch state's entries are already marked as deleted
+	wDeleted bool
+	// wFilter is the filter applied to the watch state
+	wFilter *store2diff.Filter
 }
 
+var (
+	_ store2diff.IncrementalSink = &jobRun{}
+	_ store2diff.TrackedSink     = &jobRun{}
+	_ store2diff.FilteredSink    = &jobRun{}
+)
+
 func (s *jobRun) Wait() (err error) {
-	s.nodeName, err = s.WaitRequest()
+	nodeName, err := s.WaitRequest()
+	if err != nil {
+		return
+	}
+
+	if s.state == nil || nodeName != s.nodeName {
+		s.release()
+		s.nodeName = nodeName
+		s.state = s.states.acquire(nodeName)
+		s.wRev = 0
+	}
+
 	return
 }
 
+func (s *jobRun) release() {
+	if s.state != nil {
+		s.states.release(s.state)
+		s.state = nil
+	}
+}
+
+func (s *jobRun) WatchStateReset() {
+	s.wRev = 0
+	s.wDeleted = true
+}
+
+func (s *jobRun) Filter() *store2diff.Filter {
+	if filtered, ok := s.Sink.(store2diff.FilteredSink); ok {
+		return filtered.Filter()
+	}
+	return nil
+}
+
+func (s *jobRun) LastRevision() *localnetv1.Revision {
+	if resumable, ok := s.Sink.(store2diff.ResumableSink); ok {
+		return resumable.LastRevision()
+	}
+	return nil
+}
+
+func (s *jobRun) Synced(rev *localnetv1.Revision) {
+	if tracked, ok := s.Sink.(store2diff.TrackedSink); ok {
+		tracked.Synced(rev)
+	}
+}
+
+func (s *jobRun) ResetRequested() bool {
+	if tracked, ok := s.Sink.(store2diff.TrackedSink); ok {
+		return tracked.ResetRequested()
+	}
+	return false
+}
+
+func (s *jobRun) ResetNotify() <-chan struct{} {
+	if tracked, ok := s.Sink.(store2diff.TrackedSink); ok {
+		return tracked.ResetNotify()
+	}
+	return nil
+}
+
 func (s *jobRun) Update(tx *proxystore.Tx, w *watchstate.WatchState) {
 	if !tx.AllSynced() {
 		return
 	}
 
-	nodeName := s.nodeName
-
 	ctx, task := trace.NewTask(context.Background(), "LocalState.Update")
 	defer task.End()
 
+	st := s.state
+	st.Lock()
+	defer st.Unlock()
+
+	if tx.Rev() < st.rev {
+		// viewing the past (ie: resuming a watch), don't touch the shared state
+		st = newNodeState(s.nodeName)
+	}
+
+	st.update(tx)
+	s.apply(ctx, st, w)
+}
+
+// apply updates the watch state from the node's state, only with the services changed since its last update when possible.
+func (s *jobRun) apply(ctx context.Context, st *nodeState, w *watchstate.WatchState) {
+	filter := s.Filter()
+
 	svcs := w.StoreFor(localnetv1.Set_ServicesSet)
 	seps := w.StoreFor(localnetv1.Set_EndpointsSet)
 	sepsAnonymous := w.StoreForN(localnetv1.Set_EndpointsSet, 1)
+	nodes := w.StoreFor(localnetv1.Set_NodesSet)
 
-	// set all new values
-	tx.Each(proxystore.Services, func(kv *proxystore.KV) bool {
-		key := []byte(kv.Namespace + "/" + kv.Name)
+	setService := func(key string, ss *serviceState) {
+		if !filter.MatchService(ss.service) {
+			return
+		}
 
 		if trace.IsEnabled() {
-			trace.Log(ctx, "service", string(key))
+			trace.Log(ctx, "service", key)
 		}
-		svcs.Set(key, kv.Service.Hash, kv.Service.Service)
+		svcs.Set([]byte(key), ss.hash, ss.service)
 
-		// filter endpoints for this node
-		endpointInfos, _ /* TODO external endpoints */ := endpoints.ForNode(tx, kv.Service, nodeName)
-
-		for _, ei := range endpointInfos {
-			// hash only the endpoint
-			hash := serde.Hash(ei.Endpoint)
-
-			var epKey []byte
-			var set *lightdiffstore.DiffStore
-
-			if ei.PodName == "" {
+		for _, ep := range ss.endpoints {
+			set := seps
+			if ep.anonymous {
 				set = sepsAnonymous
-				// key is service key + endpoint hash (64 bits, in hex)
-				epKey = append(make([]byte, 0, len(key)+1+64/8*2), key...)
-				epKey = append(epKey, '/')
-				epKey = strconv.AppendUint(epKey, hash, 16)
-			} else {
-				set = seps
-				// key is service key + podName
-				epKey = append(make([]byte, 0, len(key)+1+len(ei.PodName)), key...)
-				epKey = append(epKey, '/')
-				epKey = append(epKey, []byte(ei.PodName)...)
 			}
 
 			if trace.IsEnabled() {
-				trace.Log(ctx, "endpoint", string(epKey))
+				trace.Log(ctx, "endpoint", string(ep.key))
 			}
 
-			set.Set(epKey, hash, ei.Endpoint)
+			set.Set(ep.key, ep.hash, ep.endpoint)
+		}
+	}
+
+	if !filter.Equal(s.wFilter) {
+		// the unchanged services must also be added or removed
+		s.wRev = 0
+		s.wFilter = filter
+	}
+
+	if s.wRev == 0 || s.wRev < st.changesFrom {
+		// full update: set all new values, the others will be deleted
+		if !s.wDeleted {
+			w.Reset(lightdiffstore.ItemDeleted)
 		}
+		s.wDeleted = false
+
+		if st.node != nil {
+			nodes.Set([]byte(st.node.Name), st.nodeHash, st.node)
+		}
+
+		for key, ss := range st.services {
+			setService(key, ss)
+		}
+
+		s.wRev = st.rev
+		return
+	}
+
+	if st.nodeRev > s.wRev {
+		if st.node != nil {
+			nodes.Set([]byte(st.node.Name), st.nodeHash, st.node)
+		} else {
+			nodes.Delete([]byte(s.nodeName))
+		}
+	}
+
+	// replace the changed services, most recent changes first
+	seen := map[string]bool{}
+	for i := len(st.changes) - 1; i >= 0 && st.changes[i].rev > s.wRev; i-- {
+		key := st.changes[i].key
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		svcs.Delete([]byte(key))
+		seps.DeleteByPrefix([]byte(key + "/"))
+		sepsAnonymous.DeleteByPrefix([]byte(key + "/"))
+
+		if ss, ok := st.services[key]; ok {
+			setService(key, ss)
+		}
+	}
 
-		return true
-	})
+	s.wRev = st.rev
 }
 
 func (*jobRun) SendDiff(w *watchstate.WatchState) (updated bool) {
@@ -129,14 +266,17 @@ func (*jobRun) SendDiff(w *watchstate.WatchState) (updated bool) {
 	defer task.End()
 
 	count := 0
+	count += w.SendUpdates(localnetv1.Set_NodesSet)
 	count += w.SendUpdates(localnetv1.Set_ServicesSet)
 	count += w.SendDeletesN(localnetv1.Set_EndpointsSet, 1)
 	count += w.SendUpdates(localnetv1.Set_EndpointsSet)
 	count += w.SendDeletes(localnetv1.Set_EndpointsSet)
 	count += w.SendUpdatesN(localnetv1.Set_EndpointsSet, 1)
 	count += w.SendDeletes(localnetv1.Set_ServicesSet)
+	count += w.SendDeletes(localnetv1.Set_NodesSet)
 
-	w.Reset(lightdiffstore.ItemDeleted)
+	// the state is updated incrementally, so entries are kept as they are
+	w.Reset(lightdiffstore.ItemUnchanged)
 
 	return count != 0
 }
@@ -0,0 +1,266 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store2localdiff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/jobs/store2diff"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+	"sigs.k8s.io/kpng/server/pkg/server/watches"
+)
+
+var errDone = errors.New("done")
+
+// testSink prints the received ops, calling the next onWait function before each change set
+type testSink struct {
+	nodeName string
+	onWait   []func()
+}
+
+func (s *testSink) Setup() {}
+func (s *testSink) Reset() {}
+
+func (s *testSink) WaitRequest() (string, error) {
+	if len(s.onWait) == 0 {
+		return "", errDone
+	}
+	s.onWait[0]()
+	s.onWait = s.onWait[1:]
+	return s.nodeName, nil
+}
+
+func (s *testSink) Send(op *localnetv1.OpItem) error {
+	switch v := op.Op.(type) {
+	case *localnetv1.OpItem_Reset_:
+		fmt.Println("reset")
+	case *localnetv1.OpItem_Set:
+		fmt.Println("set", v.Set.Ref.Set, v.Set.Ref.Path)
+	case *localnetv1.OpItem_Delete:
+		fmt.Println("delete", v.Delete.Set, v.Delete.Path)
+	case *localnetv1.OpItem_Sync:
+		fmt.Println("sync")
+	}
+	return nil
+}
+
+func testEndpoint(svcName, podName, ip string) *localnetv1.EndpointInfo {
+	return &localnetv1.EndpointInfo{
+		Namespace:   "default",
+		SourceName:  svcName,
+		ServiceName: svcName,
+		PodName:     podName,
+		Endpoint:    &localnetv1.Endpoint{IPs: localnetv1.NewIPSet(ip)},
+		Conditions:  &localnetv1.EndpointConditions{Ready: true},
+		Topology:    &localnetv1.TopologyInfo{Node: "node-a"},
+	}
+}
+
+func ExampleJob_incremental() {
+	store := proxystore.New()
+
+	store.Update(func(tx *proxystore.Tx) {
+		tx.SetNode(&localnetv1.Node{Name: "node-a"})
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc0"})
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc1"})
+		tx.SetEndpointsOfSource("default", "svc0", []*localnetv1.EndpointInfo{testEndpoint("svc0", "pod-0", "10.1.0.1")})
+		tx.SetEndpointsOfSource("default", "svc1", []*localnetv1.EndpointInfo{testEndpoint("svc1", "pod-1", "10.1.0.2")})
+		for _, set := range proxystore.AllSets {
+			tx.SetSync(set)
+		}
+	})
+
+	states := NewNodeStates()
+
+	sink := &testSink{
+		nodeName: "node-a",
+		onWait: []func(){
+			func() { fmt.Println("-- initial state") },
+			func() {
+				fmt.Println("-- svc1 endpoint changed")
+				store.Update(func(tx *proxystore.Tx) {
+					tx.SetEndpointsOfSource("default", "svc1", []*localnetv1.EndpointInfo{testEndpoint("svc1", "pod-2", "10.1.0.3")})
+				})
+			},
+			func() {
+				fmt.Println("-- svc0 deleted")
+				store.Update(func(tx *proxystore.Tx) {
+					tx.DelService("default", "svc0")
+					tx.DelEndpointsOfSource("default", "svc0")
+				})
+			},
+		},
+	}
+
+	(&Job{Store: store, Sink: sink, States: states}).Run(context.Background())
+
+	// the state is shared with another watcher of the same node
+	fmt.Println("-- other watcher")
+	other := &testSink{nodeName: "node-a", onWait: []func(){func() {}}}
+	(&Job{Store: store, Sink: other, States: states}).Run(context.Background())
+
+	// Output:
+	// -- initial state
+	// reset
+	// set NodesSet node-a
+	// set ServicesSet default/svc0
+	// set ServicesSet default/svc1
+	// set EndpointsSet default/svc0/pod-0
+	// set EndpointsSet default/svc1/pod-1
+	// sync
+	// -- svc1 endpoint changed
+	// set EndpointsSet default/svc1/pod-2
+	// delete EndpointsSet default/svc1/pod-1
+	// sync
+	// -- svc0 deleted
+	// delete EndpointsSet default/svc0/pod-0
+	// delete ServicesSet default/svc0
+	// sync
+	// -- other watcher
+	// reset
+	// set NodesSet node-a
+	// set ServicesSet default/svc1
+	// set EndpointsSet default/svc1/pod-2
+	// sync
+}
+
+func ExampleJob_reset() {
+	store := proxystore.New()
+
+	store.Update(func(tx *proxystore.Tx) {
+		tx.SetNode(&localnetv1.Node{Name: "node-a"})
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc0"})
+		tx.SetEndpointsOfSource("default", "svc0", []*localnetv1.EndpointInfo{testEndpoint("svc0", "pod-0", "10.1.0.1")})
+		for _, set := range proxystore.AllSets {
+			tx.SetSync(set)
+		}
+	})
+
+	watch := watches.NewRegistry().Add("local", "test")
+
+	sink := struct {
+		*testSink
+		*watches.Watch
+	}{
+		testSink: &testSink{
+			nodeName: "node-a",
+			onWait: []func(){
+				func() { fmt.Println("-- initial state") },
+				func() {
+					fmt.Println("-- reset requested")
+					watch.RequestReset()
+					store.Update(func(tx *proxystore.Tx) {
+						tx.SetEndpointsOfSource("default", "svc0", []*localnetv1.EndpointInfo{testEndpoint("svc0", "pod-1", "10.1.0.2")})
+					})
+				},
+				func() {
+					fmt.Println("-- reset requested while idle")
+					go func() {
+						time.Sleep(10 * time.Millisecond)
+						watch.RequestReset()
+					}()
+				},
+			},
+		},
+		Watch: watch,
+	}
+
+	(&Job{Store: store, Sink: sink}).Run(context.Background())
+
+	fmt.Println("-- last synced revision:", watch.Info().LastRevision.Rev)
+
+	// Output:
+	// -- initial state
+	// reset
+	// set NodesSet node-a
+	// set ServicesSet default/svc0
+	// set EndpointsSet default/svc0/pod-0
+	// sync
+	// -- reset requested
+	// reset
+	// set NodesSet node-a
+	// set ServicesSet default/svc0
+	// set EndpointsSet default/svc0/pod-1
+	// sync
+	// -- reset requested while idle
+	// reset
+	// set NodesSet node-a
+	// set ServicesSet default/svc0
+	// set EndpointsSet default/svc0/pod-1
+	// sync
+	// -- last synced revision: 2
+}
+
+// filteredSink is a testSink requesting the namespaces set by its onWait functions
+type filteredSink struct {
+	*testSink
+	filter *store2diff.Filter
+}
+
+func (s *filteredSink) Filter() *store2diff.Filter {
+	return s.filter
+}
+
+func ExampleJob_filterChange() {
+	store := proxystore.New()
+
+	store.Update(func(tx *proxystore.Tx) {
+		tx.SetNode(&localnetv1.Node{Name: "node-a"})
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc0"})
+		tx.SetService(&localnetv1.Service{Namespace: "other", Name: "svc1"})
+		for _, set := range proxystore.AllSets {
+			tx.SetSync(set)
+		}
+	})
+
+	sink := &filteredSink{}
+	setNamespaces := func(namespaces ...string) {
+		sink.filter, _ = store2diff.NewFilter(&localnetv1.WatchFilter{Namespaces: namespaces})
+	}
+
+	sink.testSink = &testSink{
+		nodeName: "node-a",
+		onWait: []func(){
+			func() {
+				fmt.Println("-- default namespace")
+				setNamespaces("default")
+			},
+			func() {
+				// no store change, the new filter must still be applied
+				fmt.Println("-- other namespace")
+				setNamespaces("other")
+			},
+		},
+	}
+
+	(&Job{Store: store, Sink: sink}).Run(context.Background())
+
+	// Output:
+	// -- default namespace
+	// reset
+	// set NodesSet node-a
+	// set ServicesSet default/svc0
+	// sync
+	// -- other namespace
+	// set ServicesSet other/svc1
+	// delete ServicesSet default/svc0
+	// sync
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store2snapshot
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/proto"
+
+	"k8s.io/klog/v2"
+
+	localnetv1 "sigs.k8s.io/kpng/api/localnetv1"
+	proxystore "sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+type Config struct {
+	FilePath string
+	Interval time.Duration
+}
+
+func (c *Config) BindFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&c.FilePath, "snapshot", "", "file to save the global state to, and to restore it from at startup (disabled if not set)")
+	flags.DurationVar(&c.Interval, "snapshot-interval", time.Minute, "minimum interval between snapshots")
+}
+
+// Restore restores the store from the snapshot file, if it exists.
+func (c *Config) Restore(store *proxystore.Store) (err error) {
+	ba, err := os.ReadFile(c.FilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		klog.Info("no snapshot to restore from ", c.FilePath)
+		return nil
+	} else if err != nil {
+		return
+	}
+
+	snapshot := &localnetv1.StoreSnapshot{}
+	if err = proto.Unmarshal(ba, snapshot); err != nil {
+		return
+	}
+
+	store.Update(func(tx *proxystore.Tx) {
+		err = tx.Restore(snapshot)
+	})
+
+	return
+}
+
+// Job saves the store to the snapshot file when it changes, as long as it's synced.
+type Job struct {
+	Store  *proxystore.Store
+	Config *Config
+}
+
+func (j *Job) Run(ctx context.Context) (err error) {
+	var (
+		rev    uint64
+		closed = false
+	)
+
+	for !closed {
+		var snapshot *localnetv1.StoreSnapshot
+
+		rev, closed = j.Store.View(rev, func(tx *proxystore.Tx) {
+			if !tx.AllSynced() || tx.IsStale() {
+				return
+			}
+
+			snapshot, err = tx.Snapshot()
+		})
+
+		if err != nil {
+			return
+		}
+
+		if snapshot == nil {
+			continue
+		}
+
+		if err = j.write(snapshot); err != nil {
+			klog.Error("failed to write snapshot: ", err)
+		} else {
+			klog.V(1).Info("wrote snapshot at rev ", rev)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(j.Config.Interval):
+		}
+	}
+
+	return
+}
+
+// write writes the snapshot atomically (so a crash can't leave a partial file)
+func (j *Job) write(snapshot *localnetv1.StoreSnapshot) (err error) {
+	ba, err := proto.Marshal(snapshot)
+	if err != nil {
+		return
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(j.Config.FilePath), filepath.Base(j.Config.FilePath)+".*")
+	if err != nil {
+		return
+	}
+
+	defer os.Remove(tmp.Name()) // no-op after the rename
+
+	if _, err = tmp.Write(ba); err != nil {
+		tmp.Close()
+		return
+	}
+
+	if err = tmp.Close(); err != nil {
+		return
+	}
+
+	return os.Rename(tmp.Name(), j.Config.FilePath)
+}
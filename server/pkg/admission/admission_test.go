@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"fmt"
+
+	localnetv1 "sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+func Example() {
+	store := proxystore.New()
+	store.AddAdmitters(
+		IgnoreNamespaces("kube-system"),
+		ExternalIPsMap{"192.0.2.1": "198.51.100.1"},
+		NewValidator(),
+	)
+
+	service := func(namespace, name, clusterIP string, port int32) *localnetv1.Service {
+		return &localnetv1.Service{
+			Namespace: namespace,
+			Name:      name,
+			IPs: &localnetv1.ServiceIPs{
+				ClusterIPs:  localnetv1.NewIPSet(clusterIP),
+				ExternalIPs: localnetv1.NewIPSet("192.0.2.1"),
+			},
+			Ports: []*localnetv1.PortMapping{
+				{Protocol: localnetv1.Protocol_TCP, Port: port},
+			},
+		}
+	}
+
+	store.Update(func(tx *proxystore.Tx) {
+		tx.SetService(service("default", "web", "10.1.0.1", 80))
+		tx.SetService(service("default", "bad-port", "10.1.0.2", 70000))
+		tx.SetService(service("default", "dup-ip", "10.1.0.1", 80))
+		tx.SetService(service("kube-system", "dns", "10.1.0.10", 53))
+
+		tx.SetEndpointsOfSource("default", "web", []*localnetv1.EndpointInfo{
+			{
+				Namespace:   "default",
+				SourceName:  "web",
+				ServiceName: "web",
+				Endpoint:    &localnetv1.Endpoint{IPs: &localnetv1.IPSet{V4: []string{"10.2.0.1"}}},
+			},
+			{
+				Namespace:   "default",
+				SourceName:  "web",
+				ServiceName: "web",
+				Endpoint:    &localnetv1.Endpoint{IPs: &localnetv1.IPSet{V4: []string{"not-an-ip"}}},
+			},
+		})
+	})
+
+	// the rejected update keeps the previous value
+	store.Update(func(tx *proxystore.Tx) {
+		tx.SetService(service("default", "web", "10.1.0.1", 0))
+	})
+
+	store.View(0, func(tx *proxystore.Tx) {
+		tx.Each(proxystore.Services, func(kv *proxystore.KV) bool {
+			svc := kv.Service.Service
+			fmt.Println(svc.Namespace+"/"+svc.Name, svc.IPs.ExternalIPs.All(), svc.Ports[0].Port)
+			return true
+		})
+		tx.EachEndpointOfService("default", "web", func(ei *localnetv1.EndpointInfo) {
+			fmt.Println("endpoint", ei.Endpoint.IPs.All())
+		})
+	})
+
+	// Output:
+	// default/web [198.51.100.1] 80
+	// endpoint [10.2.0.1]
+}
+
+func ExampleValidator_clusterIPReuse() {
+	store := proxystore.New()
+	store.AddAdmitters(NewValidator())
+
+	service := func(name string) *localnetv1.Service {
+		return &localnetv1.Service{
+			Namespace: "default",
+			Name:      name,
+			IPs:       &localnetv1.ServiceIPs{ClusterIPs: localnetv1.NewIPSet("10.1.0.1")},
+		}
+	}
+
+	store.Update(func(tx *proxystore.Tx) { tx.SetService(service("a")) })
+	store.Update(func(tx *proxystore.Tx) { tx.SetService(service("b")) })
+	store.Update(func(tx *proxystore.Tx) { tx.DelService("default", "a") })
+	store.Update(func(tx *proxystore.Tx) { tx.SetService(service("b")) }) // the IP is free now
+
+	store.View(0, func(tx *proxystore.Tx) {
+		tx.Each(proxystore.Services, func(kv *proxystore.KV) bool {
+			fmt.Println(kv.Service.Service.Name)
+			return true
+		})
+	})
+
+	// Output:
+	// b
+}
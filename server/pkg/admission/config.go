@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package admission provides the built-in admitters of the store: validation and operator-defined rewrites.
+package admission
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+type Config struct {
+	Validate          bool
+	IgnoredNamespaces []string
+	ExternalIPsMap    map[string]string
+}
+
+func (c *Config) BindFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(&c.Validate, "validate", true, "reject malformed values (IPs, ports, protocols) and duplicate cluster IPs")
+	flags.StringSliceVar(&c.IgnoredNamespaces, "ignore-namespaces", nil, "namespaces whose services and endpoints are ignored")
+	flags.StringToStringVar(&c.ExternalIPsMap, "map-external-ips", nil, "external IPs to replace, as from=to pairs (an empty target removes the IP)")
+}
+
+// Admitters returns the admitters enabled by this configuration; rewrites come first so their results are validated.
+func (c *Config) Admitters() (admitters []proxystore.Admitter, err error) {
+	if len(c.IgnoredNamespaces) != 0 {
+		admitters = append(admitters, IgnoreNamespaces(c.IgnoredNamespaces...))
+	}
+
+	if len(c.ExternalIPsMap) != 0 {
+		for from, to := range c.ExternalIPsMap {
+			if net.ParseIP(from) == nil {
+				return nil, fmt.Errorf("invalid external IP to map: %q", from)
+			}
+			if to != "" && net.ParseIP(to) == nil {
+				return nil, fmt.Errorf("invalid external IP to map %s to: %q", from, to)
+			}
+		}
+		admitters = append(admitters, ExternalIPsMap(c.ExternalIPsMap))
+	}
+
+	if c.Validate {
+		admitters = append(admitters, NewValidator())
+	}
+
+	return
+}
+
+// Setup adds the admitters enabled by this configuration to the store.
+func (c *Config) Setup(store *proxystore.Store) error {
+	admitters, err := c.Admitters()
+	if err != nil {
+		return err
+	}
+
+	store.AddAdmitters(admitters...)
+	return nil
+}
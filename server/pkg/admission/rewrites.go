@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	localnetv1 "sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+// IgnoredNamespaces drops the services and endpoints of the given namespaces.
+type IgnoredNamespaces map[string]bool
+
+var (
+	_ proxystore.ServiceAdmitter  = IgnoredNamespaces{}
+	_ proxystore.EndpointAdmitter = IgnoredNamespaces{}
+)
+
+func IgnoreNamespaces(namespaces ...string) IgnoredNamespaces {
+	ns := make(IgnoredNamespaces, len(namespaces))
+	for _, namespace := range namespaces {
+		ns[namespace] = true
+	}
+	return ns
+}
+
+func (ns IgnoredNamespaces) Name() string {
+	return "ignored-namespaces"
+}
+
+func (ns IgnoredNamespaces) AdmitService(tx *proxystore.Tx, svc *localnetv1.Service) error {
+	if ns[svc.Namespace] {
+		return proxystore.ErrIgnored
+	}
+	return nil
+}
+
+func (ns IgnoredNamespaces) AdmitEndpoint(tx *proxystore.Tx, ei *localnetv1.EndpointInfo) error {
+	if ns[ei.Namespace] {
+		return proxystore.ErrIgnored
+	}
+	return nil
+}
+
+// ExternalIPsMap replaces the services' external IPs (keys) by other IPs (values).
+// An IP mapped to an empty value is removed.
+type ExternalIPsMap map[string]string
+
+var _ proxystore.ServiceAdmitter = ExternalIPsMap{}
+
+func (m ExternalIPsMap) Name() string {
+	return "external-ips-map"
+}
+
+func (m ExternalIPsMap) AdmitService(tx *proxystore.Tx, svc *localnetv1.Service) error {
+	if svc.IPs == nil || svc.IPs.ExternalIPs == nil {
+		return nil
+	}
+
+	ips := svc.IPs.ExternalIPs.All()
+
+	mapped := false
+	for _, ip := range ips {
+		if _, ok := m[ip]; ok {
+			mapped = true
+			break
+		}
+	}
+
+	if !mapped {
+		return nil
+	}
+
+	externalIPs := &localnetv1.IPSet{}
+	for _, ip := range ips {
+		if to, ok := m[ip]; ok {
+			ip = to
+		}
+		if ip != "" {
+			externalIPs.Add(ip)
+		}
+	}
+
+	svc.IPs.ExternalIPs = externalIPs
+
+	return nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	localnetv1 "sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+// Validator rejects malformed values (IPs, ports, protocols) and services reusing another service's cluster IP.
+// A Validator must not be shared between stores.
+type Validator struct {
+	// last known owner of each cluster IP; entries are checked against the store since services can be deleted
+	clusterIPs map[string]serviceRef
+}
+
+type serviceRef struct {
+	namespace, name string
+}
+
+func (r serviceRef) String() string {
+	return r.namespace + "/" + r.name
+}
+
+var (
+	_ proxystore.ServiceAdmitter  = &Validator{}
+	_ proxystore.EndpointAdmitter = &Validator{}
+	_ proxystore.NodeAdmitter     = &Validator{}
+)
+
+func NewValidator() *Validator {
+	return &Validator{
+		clusterIPs: map[string]serviceRef{},
+	}
+}
+
+func (v *Validator) Name() string {
+	return "validator"
+}
+
+func (v *Validator) AdmitService(tx *proxystore.Tx, svc *localnetv1.Service) (err error) {
+	if svc.Namespace == "" || svc.Name == "" {
+		return errors.New("namespace and name are required")
+	}
+
+	if ips := svc.IPs; ips != nil {
+		if err = validateIPSet("cluster IPs", ips.ClusterIPs); err != nil {
+			return
+		}
+		if err = validateIPSet("external IPs", ips.ExternalIPs); err != nil {
+			return
+		}
+		if err = validateIPSet("load-balancer IPs", ips.LoadBalancerIPs); err != nil {
+			return
+		}
+	}
+
+	for _, port := range svc.Ports {
+		if err = validatePort(port); err != nil {
+			return fmt.Errorf("port %q: %w", port.Name, err)
+		}
+	}
+
+	if svc.IPs == nil || svc.IPs.ClusterIPs == nil {
+		return
+	}
+
+	ref := serviceRef{svc.Namespace, svc.Name}
+	clusterIPs := svc.IPs.ClusterIPs.All()
+
+	for _, ip := range clusterIPs {
+		owner, ok := v.clusterIPs[ip]
+		if ok && owner != ref && hasClusterIP(tx, owner, ip) {
+			return fmt.Errorf("cluster IP %s is already used by %s", ip, owner)
+		}
+	}
+
+	for _, ip := range clusterIPs {
+		v.clusterIPs[ip] = ref
+	}
+
+	return
+}
+
+func hasClusterIP(tx *proxystore.Tx, ref serviceRef, ip string) bool {
+	si := tx.GetService(ref.namespace, ref.name)
+	if si == nil || si.Service.IPs == nil || si.Service.IPs.ClusterIPs == nil {
+		return false
+	}
+
+	for _, svcIP := range si.Service.IPs.ClusterIPs.All() {
+		if svcIP == ip {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *Validator) AdmitEndpoint(tx *proxystore.Tx, ei *localnetv1.EndpointInfo) (err error) {
+	ep := ei.Endpoint
+	if ep == nil || ep.IPs == nil || ep.IPs.IsEmpty() {
+		return errors.New("endpoint has no IP")
+	}
+
+	if err = validateIPSet("IPs", ep.IPs); err != nil {
+		return
+	}
+
+	for name, port := range ep.PortOverrides {
+		if !validPortNumber(port) {
+			return fmt.Errorf("port %q: invalid port number %d", name, port)
+		}
+	}
+
+	return
+}
+
+func (v *Validator) AdmitNode(tx *proxystore.Tx, node *localnetv1.Node) (err error) {
+	if node.Name == "" {
+		return errors.New("name is required")
+	}
+
+	for _, addr := range node.Addresses {
+		switch addr.Type {
+		case "InternalIP", "ExternalIP":
+			if net.ParseIP(addr.Address) == nil {
+				return fmt.Errorf("invalid %s: %q", addr.Type, addr.Address)
+			}
+		}
+	}
+
+	for _, cidr := range node.PodCIDRs {
+		if _, _, err = net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid pod CIDR: %w", err)
+		}
+	}
+
+	return
+}
+
+func validateIPSet(name string, set *localnetv1.IPSet) error {
+	if set == nil {
+		return nil
+	}
+
+	for _, s := range set.V4 {
+		if ip := net.ParseIP(s); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("%s: invalid IPv4 %q", name, s)
+		}
+	}
+	for _, s := range set.V6 {
+		if ip := net.ParseIP(s); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("%s: invalid IPv6 %q", name, s)
+		}
+	}
+
+	return nil
+}
+
+func validatePort(port *localnetv1.PortMapping) error {
+	switch port.Protocol {
+	case localnetv1.Protocol_TCP, localnetv1.Protocol_UDP, localnetv1.Protocol_SCTP:
+	default:
+		return fmt.Errorf("invalid protocol %v", port.Protocol)
+	}
+
+	if !validPortNumber(port.Port) {
+		return fmt.Errorf("invalid port number %d", port.Port)
+	}
+	if port.NodePort != 0 && !validPortNumber(port.NodePort) {
+		return fmt.Errorf("invalid node port %d", port.NodePort)
+	}
+	if port.TargetPort != 0 && !validPortNumber(port.TargetPort) {
+		return fmt.Errorf("invalid target port %d", port.TargetPort)
+	}
+
+	return nil
+}
+
+func validPortNumber(port int32) bool {
+	return port > 0 && port <= 65535
+}
@@ -17,37 +17,28 @@ limitations under the License.
 package apiwatch
 
 import (
+	"time"
+
 	"github.com/spf13/pflag"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 
-	"sigs.k8s.io/kpng/client/tlsflags"
+	"sigs.k8s.io/kpng/client/apiconn"
 )
 
+// ErrFailBack is returned by the watches leaving a fallback server for the preferred one.
+var ErrFailBack = apiconn.ErrFailBack
+
+// Watch handles the connections of a watch to the remote API (see apiconn.Watch), with the flags
+// of the server's jobs.
 type Watch struct {
-	Server   string
-	TLSFlags *tlsflags.Flags
+	apiconn.Watch
 }
 
 func (w *Watch) BindFlags(flags *pflag.FlagSet) {
-	flags.StringVar(&w.Server, "api", "127.0.0.1:12090", "Remote API server to query")
+	flags.StringVar(&w.Server, "api", "127.0.0.1:12090", "Remote API server to query (can be a comma separated list, in order of preference)")
+	flags.DurationVar(&w.ErrorDelay, "api-error-delay", time.Second, "duration to wait before retrying after errors (doubled after each error)")
+	flags.DurationVar(&w.MaxErrorDelay, "api-max-error-delay", 30*time.Second, "maximum duration to wait before retrying after errors")
+	flags.DurationVar(&w.KeepaliveTime, "api-keepalive", 30*time.Second, "interval of the keepalive pings to the API (0 to disable)")
+	flags.DurationVar(&w.SyncTimeout, "api-sync-timeout", time.Minute, "maximum duration to receive a change set before reconnecting (0 to disable)")
+	flags.DurationVar(&w.FailBackDelay, "api-fail-back-delay", 5*time.Minute, "duration after which a fallback API server is left for the first one (0 to disable)")
 	w.TLSFlags.Bind(flags, "api-client-")
 }
-
-func (w *Watch) Dial() (conn *grpc.ClientConn, err error) {
-	// connect to API
-	opts := []grpc.DialOption{}
-
-	if cfg := w.TLSFlags.Config(); cfg == nil {
-		opts = append(opts, grpc.WithInsecure())
-	} else {
-		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(cfg)))
-	}
-
-	conn, err = grpc.Dial(w.Server, opts...)
-	if err != nil {
-		return
-	}
-
-	return
-}
@@ -17,6 +17,8 @@ limitations under the License.
 package endpoints
 
 import (
+	"strconv"
+
 	"google.golang.org/protobuf/proto"
 
 	localnetv1 "sigs.k8s.io/kpng/api/localnetv1"
@@ -42,47 +44,122 @@ func ForNode(tx *proxystore.Tx, si *localnetv1.ServiceInfo, nodeName string) (in
 
 	infos := make([]*localnetv1.EndpointInfo, 0)
 	tx.EachEndpointOfService(svc.Namespace, svc.Name, func(info *localnetv1.EndpointInfo) {
+		if !info.Conditions.GetReady() && !info.Conditions.IsServingTerminating() {
+			return
+		}
+
 		info = proto.Clone(info).(*localnetv1.EndpointInfo)
 
 		info.Endpoint.Local = info.Topology.Node == nodeName
+		info.Endpoint.Terminating = info.Conditions.GetTerminating()
 
-		if !info.Conditions.Ready {
-			return
-		}
+		infos = append(infos, info)
+	})
+
+	// select endpoints for this service
+	internalEndpoints = selectEndpoints(infos, node, svc.InternalTrafficToLocal)
+	externalEndpoints = selectEndpoints(infos, node, svc.ExternalTrafficToLocal)
+
+	// flag the endpoints (they're shared by both lists when eligible for both)
+	for _, info := range infos {
+		info.Endpoint.NotInternal = true
+		info.Endpoint.NotExternal = true
+	}
+	for _, info := range internalEndpoints {
+		info.Endpoint.NotInternal = false
+	}
+	for _, info := range externalEndpoints {
+		info.Endpoint.NotExternal = false
+	}
 
-		if hints := info.Hints; hints != nil {
-			if len(hints.Zones) != 0 {
-				// filter by zone
-				isForNodeZone := false
-				for _, z := range hints.Zones {
-					if z == node.Topology.Zone {
-						isForNodeZone = true
-						break
-					}
-				}
-
-				if !isForNodeZone {
-					return
-				}
-			}
+	return
+}
+
+// LocalForNode returns the endpoints of the service for the node (see ForNode) as the local API sends them:
+// each endpoint once, flagged with its eligibility for internal and/or external traffic.
+func LocalForNode(tx *proxystore.Tx, si *localnetv1.ServiceInfo, nodeName string) (infos []*localnetv1.EndpointInfo) {
+	internalInfos, externalInfos := ForNode(tx, si, nodeName)
+
+	infos = internalInfos
+	for _, ei := range externalInfos {
+		if ei.Endpoint.NotInternal {
+			infos = append(infos, ei)
 		}
+	}
 
-		infos = append(infos, info)
-	})
+	return
+}
 
-	internalEndpoints = make([]*localnetv1.EndpointInfo, 0, len(infos))
-	externalEndpoints = make([]*localnetv1.EndpointInfo, 0, len(infos))
+// LocalKey returns the key of an endpoint in the local API's EndpointsSet, from its service's key
+// ("namespace/name"), its pod name and its hash (only used if the pod name is empty).
+func LocalKey(svcKey []byte, podName string, hash uint64) (epKey []byte) {
+	if podName == "" {
+		// key is service key + endpoint hash (64 bits, in hex)
+		epKey = append(make([]byte, 0, len(svcKey)+1+64/8*2), svcKey...)
+		epKey = append(epKey, '/')
+		epKey = strconv.AppendUint(epKey, hash, 16)
+	} else {
+		// key is service key + podName
+		epKey = append(make([]byte, 0, len(svcKey)+1+len(podName)), svcKey...)
+		epKey = append(epKey, '/')
+		epKey = append(epKey, []byte(podName)...)
+	}
 
-	// select endpoints for this service
+	return
+}
+
+// selectEndpoints returns the ready endpoints usable from the node, falling
+// back to the serving terminating ones when no ready endpoint exists at all
+// (see the "proxy terminating endpoints" KEP-1669). Zone hints only narrow the
+// ready endpoints, and are ignored when they would leave none.
+func selectEndpoints(infos []*localnetv1.EndpointInfo, node *localnetv1.Node, localOnly bool) (selected []*localnetv1.EndpointInfo) {
+	ready := make([]*localnetv1.EndpointInfo, 0, len(infos))
+	terminating := make([]*localnetv1.EndpointInfo, 0)
 
 	for _, info := range infos {
-		if !(si.Service.InternalTrafficToLocal && !info.Endpoint.Local) {
-			internalEndpoints = append(internalEndpoints, info)
+		if localOnly && !info.Endpoint.Local {
+			continue
+		}
+
+		if info.Conditions.Ready {
+			ready = append(ready, info)
+		} else if info.Conditions.IsServingTerminating() {
+			terminating = append(terminating, info)
 		}
-		if !(si.Service.ExternalTrafficToLocal && !info.Endpoint.Local) {
-			externalEndpoints = append(externalEndpoints, info)
+	}
+
+	if len(ready) == 0 {
+		// no ready endpoint, fallback to terminating ones (ignoring topology as this is a best effort)
+		return terminating
+	}
+
+	selected = make([]*localnetv1.EndpointInfo, 0, len(ready))
+	for _, info := range ready {
+		if isForNodeZone(info, node) {
+			selected = append(selected, info)
 		}
 	}
 
+	if len(selected) == 0 {
+		// no endpoint hinted for this zone, ignore the hints
+		selected = ready
+	}
+
 	return
 }
+
+func isForNodeZone(info *localnetv1.EndpointInfo, node *localnetv1.Node) bool {
+	hints := info.Hints
+	if hints == nil || len(hints.Zones) == 0 {
+		return true
+	}
+
+	// filter by zone
+	for _, z := range hints.Zones {
+		if z == node.Topology.Zone {
+			return true
+		}
+	}
+
+	return false
+}
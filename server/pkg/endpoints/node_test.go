@@ -93,3 +93,130 @@ func ExampleForNodeWithTopology() {
 	//   - service test:
 	//     - ep V4:"10.2.1.1"
 }
+
+func ExampleForNode_withTerminating() {
+	store := proxystore.New()
+
+	endpoint := func(ip, node string, conditions *localnetv1.EndpointConditions) *localnetv1.EndpointInfo {
+		return &localnetv1.EndpointInfo{
+			Namespace:   "test",
+			SourceName:  "test-abcde",
+			ServiceName: "test",
+			Endpoint:    &localnetv1.Endpoint{IPs: localnetv1.NewIPSet(ip)},
+			Topology:    &localnetv1.TopologyInfo{Node: node},
+			Conditions:  conditions,
+		}
+	}
+
+	var (
+		ready       = &localnetv1.EndpointConditions{Ready: true, Serving: true}
+		terminating = &localnetv1.EndpointConditions{Serving: true, Terminating: true}
+		notServing  = &localnetv1.EndpointConditions{Terminating: true}
+	)
+
+	store.Update(func(tx *proxystore.Tx) {
+		tx.SetService(&localnetv1.Service{
+			Namespace: "test",
+			Name:      "test",
+			Type:      "LoadBalancer",
+			IPs:       &localnetv1.ServiceIPs{ClusterIPs: localnetv1.NewIPSet("10.1.2.3")},
+			Ports: []*localnetv1.PortMapping{
+				{Port: 1234},
+			},
+
+			ExternalTrafficToLocal: true,
+		})
+
+		tx.SetEndpointsOfSource("test", "test-abcde", []*localnetv1.EndpointInfo{
+			endpoint("10.2.0.1", "host-a", terminating),
+			endpoint("10.2.0.2", "host-a", notServing),
+			endpoint("10.2.1.1", "host-b", ready),
+		})
+	})
+
+	store.View(0, func(tx *proxystore.Tx) {
+		tx.Each(proxystore.Services, func(kv *proxystore.KV) (cont bool) {
+			for _, host := range []string{"host-a", "host-b"} {
+				fmt.Print("host ", host, ":\n")
+
+				internal, external := ForNode(tx, kv.Service, host)
+				for _, epi := range internal {
+					fmt.Print("  - internal ep ", epi.Endpoint.IPs, " terminating: ", epi.Endpoint.Terminating, "\n")
+				}
+				for _, epi := range external {
+					fmt.Print("  - external ep ", epi.Endpoint.IPs, " terminating: ", epi.Endpoint.Terminating, "\n")
+				}
+			}
+			return true
+		})
+	})
+
+	// Output:
+	// host host-a:
+	//   - internal ep V4:"10.2.1.1" terminating: false
+	//   - external ep V4:"10.2.0.1" terminating: true
+	// host host-b:
+	//   - internal ep V4:"10.2.1.1" terminating: false
+	//   - external ep V4:"10.2.1.1" terminating: false
+}
+
+func ExampleForNode_withZoneHints() {
+	store := proxystore.New()
+
+	endpoint := func(ip, zone string, conditions *localnetv1.EndpointConditions) *localnetv1.EndpointInfo {
+		return &localnetv1.EndpointInfo{
+			Namespace:   "test",
+			SourceName:  "test-abcde",
+			ServiceName: "test",
+			Endpoint:    &localnetv1.Endpoint{IPs: localnetv1.NewIPSet(ip)},
+			Topology:    &localnetv1.TopologyInfo{Node: "host-" + zone, Zone: zone},
+			Conditions:  conditions,
+			Hints:       &localnetv1.TopologyHints{Zones: []string{zone}},
+		}
+	}
+
+	var (
+		ready       = &localnetv1.EndpointConditions{Ready: true, Serving: true}
+		terminating = &localnetv1.EndpointConditions{Serving: true, Terminating: true}
+	)
+
+	store.Update(func(tx *proxystore.Tx) {
+		tx.SetNode(&localnetv1.Node{Name: "host-a", Topology: &localnetv1.TopologyInfo{Node: "host-a", Zone: "a"}})
+		tx.SetNode(&localnetv1.Node{Name: "host-c", Topology: &localnetv1.TopologyInfo{Node: "host-c", Zone: "c"}})
+
+		tx.SetService(&localnetv1.Service{
+			Namespace: "test",
+			Name:      "test",
+			Type:      "ClusterIP",
+			IPs:       &localnetv1.ServiceIPs{ClusterIPs: localnetv1.NewIPSet("10.1.2.3")},
+			Ports: []*localnetv1.PortMapping{
+				{Port: 1234},
+			},
+		})
+
+		tx.SetEndpointsOfSource("test", "test-abcde", []*localnetv1.EndpointInfo{
+			endpoint("10.2.0.1", "a", terminating),
+			endpoint("10.2.1.1", "b", ready),
+		})
+	})
+
+	store.View(0, func(tx *proxystore.Tx) {
+		tx.Each(proxystore.Services, func(kv *proxystore.KV) (cont bool) {
+			for _, host := range []string{"host-a", "host-c"} {
+				fmt.Print("host ", host, ":\n")
+
+				internal, _ := ForNode(tx, kv.Service, host)
+				for _, epi := range internal {
+					fmt.Print("  - internal ep ", epi.Endpoint.IPs, " terminating: ", epi.Endpoint.Terminating, "\n")
+				}
+			}
+			return true
+		})
+	})
+
+	// Output:
+	// host host-a:
+	//   - internal ep V4:"10.2.1.1" terminating: false
+	// host host-c:
+	//   - internal ep V4:"10.2.1.1" terminating: false
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package monitoring serves the metrics and health endpoints over HTTP.
+package monitoring
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kpng/server/pkg/server"
+)
+
+type Config struct {
+	BindSpec string
+}
+
+func (c *Config) BindFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&c.BindSpec, "metrics-listen", "", "serve the metrics (/metrics) and health (/healthz, /readyz) endpoints (disabled if not set, ie: tcp://:12091)")
+}
+
+func (c *Config) Enabled() bool {
+	return c.BindSpec != ""
+}
+
+// Run serves the endpoints until the context is done. /readyz reports the result of ready (always ready if nil).
+func (c *Config) Run(ctx context.Context, ready func() bool) error {
+	srv := &http.Server{Handler: Handler(ready)}
+
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	err := srv.Serve(server.MustListen(c.BindSpec))
+	if err == http.ErrServerClosed {
+		err = nil
+	}
+	return err
+}
+
+// Handler returns the handler of the metrics and health endpoints.
+func Handler(ready func() bool) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.Handle("/metrics", promhttp.Handler())
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok\n"))
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		if ready != nil && !ready() {
+			http.Error(w, "not synced", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok\n"))
+	})
+
+	return mux
+}
@@ -31,6 +31,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
 
+	"sigs.k8s.io/kpng/client/apiconn"
 	"sigs.k8s.io/kpng/client/tlsflags"
 	"sigs.k8s.io/kpng/server/pkg/proxystore"
 )
@@ -111,13 +112,13 @@ func NewServer() (srv *Server, err error) {
 
 	// setup gRPC server
 	if tlsCfg := tlsFlags.Config(); tlsCfg == nil {
-		srv.GRPC = grpc.NewServer()
+		srv.GRPC = grpc.NewServer(apiconn.ServerOptions()...)
 	} else {
 		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
 		tlsCfg.ClientCAs = tlsCfg.RootCAs
 
 		creds := credentials.NewTLS(tlsCfg)
-		srv.GRPC = grpc.NewServer(grpc.Creds(creds))
+		srv.GRPC = grpc.NewServer(append(apiconn.ServerOptions(), grpc.Creds(creds))...)
 	}
 
 	return
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxystore
+
+import (
+	"errors"
+
+	"k8s.io/klog/v2"
+
+	localnetv1 "sigs.k8s.io/kpng/api/localnetv1"
+)
+
+// ErrIgnored can be returned by an admitter to drop a value without considering it invalid.
+// Any previous version of the value is removed from the store.
+var ErrIgnored = errors.New("ignored")
+
+// Admitter validates and/or mutates the values set in the store. It must also implement
+// at least one of ServiceAdmitter, EndpointAdmitter or NodeAdmitter.
+//
+// An admitter returning an error rejects the value: the previous version of the value (if
+// any) is kept in the store, except for endpoints that are replaced by source.
+type Admitter interface {
+	// Name of the admitter, as used in logs and metrics
+	Name() string
+}
+
+type ServiceAdmitter interface {
+	AdmitService(tx *Tx, svc *localnetv1.Service) error
+}
+
+type EndpointAdmitter interface {
+	AdmitEndpoint(tx *Tx, ei *localnetv1.EndpointInfo) error
+}
+
+type NodeAdmitter interface {
+	AdmitNode(tx *Tx, node *localnetv1.Node) error
+}
+
+// AddAdmitters appends admitters to the chain called before values are set in the store.
+// Admitters are called in order, each one seeing the changes of the previous ones.
+func (s *Store) AddAdmitters(admitters ...Admitter) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.admitters = append(s.admitters, admitters...)
+}
+
+func (tx *Tx) admitService(svc *localnetv1.Service) error {
+	for _, a := range tx.s.admitters {
+		if sa, ok := a.(ServiceAdmitter); ok {
+			if err := sa.AdmitService(tx, svc); err != nil {
+				return rejected(a, Services, svc.Namespace+"/"+svc.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (tx *Tx) admitEndpoint(ei *localnetv1.EndpointInfo) error {
+	for _, a := range tx.s.admitters {
+		if ea, ok := a.(EndpointAdmitter); ok {
+			if err := ea.AdmitEndpoint(tx, ei); err != nil {
+				return rejected(a, Endpoints, ei.Namespace+"/"+ei.SourceName, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (tx *Tx) admitNode(node *localnetv1.Node) error {
+	for _, a := range tx.s.admitters {
+		if na, ok := a.(NodeAdmitter); ok {
+			if err := na.AdmitNode(tx, node); err != nil {
+				return rejected(a, Nodes, node.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func rejected(a Admitter, set Set, ref string, err error) error {
+	if errors.Is(err, ErrIgnored) {
+		if log := klog.V(4); log.Enabled() {
+			log.Infof("%s: ignored %s %s", a.Name(), setLabels[set], ref)
+		}
+		return err
+	}
+
+	klog.Warningf("%s: rejected %s %s: %v", a.Name(), setLabels[set], ref, err)
+	rejectionsCount.WithLabelValues(a.Name(), setLabels[set]).Inc()
+
+	return err
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxystore
+
+// DefaultJournalSize is the default number of changes kept in the journal.
+const DefaultJournalSize = 10000
+
+// journal keeps the latest changes of the store, allowing to view the store as
+// it was at a previous revision (ie: to resume a watch after a reconnection).
+type journal struct {
+	// size is the maximum number of changes retained
+	size int
+	// count is the number of changes currently retained
+	count int
+	// minRev is the oldest revision that can be restored
+	minRev uint64
+
+	revs []journalRev
+}
+
+type journalRev struct {
+	rev uint64
+	// count of changes in this revision (including sync changes)
+	count int
+	// sync state before this revision
+	sync    map[Set]bool
+	changes []journalChange
+}
+
+type journalChange struct {
+	key *KV
+	// prev is the value before the change (nil if there was no value)
+	prev *KV
+}
+
+func (j *journal) add(jr journalRev) {
+	j.revs = append(j.revs, jr)
+	j.count += jr.count
+
+	for len(j.revs) != 0 && j.count > j.size {
+		j.count -= j.revs[0].count
+		j.minRev = j.revs[0].rev
+
+		j.revs[0] = journalRev{} // release the memory
+		j.revs = j.revs[1:]
+	}
+}
+
+// truncate forgets every change up to the given revision.
+func (j *journal) truncate(rev uint64) {
+	j.revs = nil
+	j.count = 0
+	j.minRev = rev
+}
+
+// SetJournalSize sets the maximum number of changes retained to view previous revisions.
+func (s *Store) SetJournalSize(size int) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.journal.size = size
+	if size <= 0 {
+		s.journal.truncate(s.rev)
+	}
+}
+
+// Epoch returns the epoch of this store; revisions of stores with different epochs are unrelated.
+func (s *Store) Epoch() uint64 {
+	return s.epoch
+}
+
+// Rev returns the current revision of the store.
+func (s *Store) Rev() uint64 {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.rev
+}
+
+// ChangesCountSince returns the number of changes after the given revision.
+// Returns false if the changes are not known anymore (or not tracked at all, when the journal is disabled).
+func (s *Store) ChangesCountSince(rev uint64) (count int, ok bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	j := &s.journal
+
+	if j.size <= 0 || rev < j.minRev || rev > s.rev {
+		return 0, false
+	}
+
+	for _, jr := range j.revs {
+		if jr.rev > rev {
+			count += jr.count
+		}
+	}
+
+	return count, true
+}
+
+// ViewRevision calls view with the store as it was at the given revision.
+// Returns false if the revision can't be restored (unknown epoch or forgotten revision).
+func (s *Store) ViewRevision(epoch, rev uint64, view func(tx *Tx)) (ok bool) {
+	past, revs := s.pastStore(epoch, rev)
+	if past == nil {
+		return false
+	}
+
+	// undo the changes on the clone, most recent first
+	for i := len(revs) - 1; i >= 0; i-- {
+		jr := revs[i]
+
+		for c := len(jr.changes) - 1; c >= 0; c-- {
+			change := jr.changes[c]
+
+			if change.prev == nil {
+				past.tree.Delete(change.key)
+			} else {
+				past.tree.ReplaceOrInsert(change.prev)
+			}
+		}
+
+		past.sync = jr.sync
+	}
+
+	view(&Tx{s: past, ro: true})
+
+	return true
+}
+
+// pastStore returns a lazy clone of the store and the journal revisions to undo on it to
+// get back to the given revision (nil if it can't be restored).
+func (s *Store) pastStore(epoch, rev uint64) (past *Store, revs []journalRev) {
+	// cloning the tree updates its copy-on-write context, so it needs the write lock
+	s.Lock()
+	defer s.Unlock()
+
+	if s.closed || epoch != s.epoch || rev < s.journal.minRev || rev > s.rev {
+		return nil, nil
+	}
+
+	past = &Store{
+		rev:  rev,
+		tree: s.tree.Clone(),
+		sync: make(map[Set]bool, len(s.sync)),
+	}
+
+	for set, synced := range s.sync {
+		past.sync[set] = synced
+	}
+
+	// copy the revisions as the journal releases them in place
+	for i, jr := range s.journal.revs {
+		if jr.rev > rev {
+			revs = append(revs, s.journal.revs[i:]...)
+			break
+		}
+	}
+
+	return
+}
+
+// ChangesSince calls callback with the key of each value changed after the given revision (a key
+// can be given multiple times). Returns false if the changes are not known anymore (or not tracked
+// at all, when the journal is disabled).
+func (tx *Tx) ChangesSince(rev uint64, callback func(key *KV)) (ok bool) {
+	j := &tx.s.journal
+
+	if j.size <= 0 || rev < j.minRev || rev > tx.s.rev {
+		return false
+	}
+
+	for _, jr := range j.revs {
+		if jr.rev <= rev {
+			continue
+		}
+
+		for _, change := range jr.changes {
+			callback(change.key)
+		}
+	}
+
+	return true
+}
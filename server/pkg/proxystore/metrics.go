@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxystore
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	revisionGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kpng",
+		Subsystem: "store",
+		Name:      "revision",
+		Help:      "Current revision of the store",
+	})
+
+	entriesGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kpng",
+		Subsystem: "store",
+		Name:      "entries",
+		Help:      "Number of entries in the store by set (endpoints are indexed twice)",
+	}, []string{"set"})
+
+	syncedGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kpng",
+		Subsystem: "store",
+		Name:      "synced",
+		Help:      "1 if the set is synced with its source, 0 otherwise",
+	}, []string{"set"})
+
+	staleGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kpng",
+		Subsystem: "store",
+		Name:      "stale",
+		Help:      "1 if the store has values restored from a snapshot that are not confirmed by their source yet, 0 otherwise",
+	})
+
+	updateDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "kpng",
+		Subsystem: "store",
+		Name:      "update_duration_seconds",
+		Help:      "Duration of the store updates, including the wait for the store's lock",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+	})
+
+	changesCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "kpng",
+		Subsystem: "store",
+		Name:      "changes_total",
+		Help:      "Number of changes applied to the store",
+	})
+
+	rejectionsCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kpng",
+		Subsystem: "store",
+		Name:      "rejections_total",
+		Help:      "Number of values rejected by the store's admitters",
+	}, []string{"admitter", "set"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		revisionGauge,
+		entriesGauge,
+		syncedGauge,
+		staleGauge,
+		updateDuration,
+		changesCount,
+		rejectionsCount,
+	)
+}
+
+var setLabels = map[Set]string{
+	Services:  "services",
+	Endpoints: "endpoints",
+	Nodes:     "nodes",
+}
+
+// observeUpdate records the metrics of an update; the store must be locked.
+func (s *Store) observeUpdate(start time.Time, changes uint) {
+	updateDuration.Observe(time.Since(start).Seconds())
+
+	// dropping the stale values of a set may not change anything
+	stale := 0.
+	if s.stale != nil {
+		stale = 1
+	}
+	staleGauge.Set(stale)
+
+	if changes == 0 {
+		return
+	}
+
+	changesCount.Add(float64(changes))
+	revisionGauge.Set(float64(s.rev))
+
+	for _, set := range AllSets {
+		entriesGauge.WithLabelValues(setLabels[set]).Set(float64(s.entries[set]))
+
+		synced := 0.
+		if s.sync[set] {
+			synced = 1
+		}
+		syncedGauge.WithLabelValues(setLabels[set]).Set(synced)
+	}
+}
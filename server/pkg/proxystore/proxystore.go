@@ -17,9 +17,11 @@ limitations under the License.
 package proxystore
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/google/btree"
 	"k8s.io/klog/v2"
@@ -30,13 +32,28 @@ import (
 
 type Store struct {
 	sync.RWMutex
-	c      *sync.Cond
-	rev    uint64
-	closed bool
-	tree   *btree.BTree
+	tree *btree.BTree
+
+	// rev and closed are written with revL held; changed is closed (and replaced) to wake up the waiters
+	revL    sync.Mutex
+	rev     uint64
+	closed  bool
+	changed chan struct{}
 
 	// set sync info
 	sync map[Set]bool
+
+	// number of entries by set
+	entries map[Set]int
+
+	epoch   uint64
+	journal journal
+
+	// values restored from a snapshot and not confirmed yet, by set and path (nil if none)
+	stale map[Set]map[string]bool
+
+	// admission chain of the values set (see AddAdmitters)
+	admitters []Admitter
 }
 
 type Set = localnetv1.Set
@@ -55,24 +72,48 @@ type Hashed interface {
 
 func New() *Store {
 	return &Store{
-		c:    sync.NewCond(&sync.Mutex{}),
 		tree: btree.New(2),
 		sync: map[Set]bool{},
+
+		changed: make(chan struct{}),
+
+		entries: map[Set]int{},
+
+		epoch:   uint64(time.Now().UnixNano()),
+		journal: journal{size: DefaultJournalSize},
 	}
 }
 
 func (s *Store) Close() {
-	s.c.L.Lock()
+	s.revL.Lock()
 	s.closed = true
-	s.c.Broadcast()
-	s.c.L.Unlock()
+	s.notify()
+	s.revL.Unlock()
+}
+
+// notify wakes up the waiters; s.revL must be held.
+func (s *Store) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
 }
 
 func (s *Store) Update(update func(tx *Tx)) {
+	start := time.Now()
+
 	s.Lock()
 	defer s.Unlock()
 
-	tx := &Tx{s: s}
+	tx := &Tx{s: s, journal: s.journal.size > 0}
+	defer func() { s.observeUpdate(start, tx.changes) }()
+
+	var prevSync map[Set]bool
+	if tx.journal {
+		prevSync = make(map[Set]bool, len(s.sync))
+		for set, isSync := range s.sync {
+			prevSync[set] = isSync
+		}
+	}
+
 	update(tx)
 
 	if tx.changes == 0 {
@@ -80,10 +121,22 @@ func (s *Store) Update(update func(tx *Tx)) {
 	}
 
 	// TODO check if the update really updated something
-	s.c.L.Lock()
+	s.revL.Lock()
 	s.rev++
-	s.c.Broadcast()
-	s.c.L.Unlock()
+	s.notify()
+	s.revL.Unlock()
+
+	switch {
+	case !tx.journal || tx.reset:
+		s.journal.truncate(s.rev)
+	default:
+		s.journal.add(journalRev{
+			rev:     s.rev,
+			count:   int(tx.changes),
+			sync:    prevSync,
+			changes: tx.journalChanges,
+		})
+	}
 
 	if log := klog.V(3); log.Enabled() {
 		log.Info("store updated to rev ", s.rev, " with ", s.tree.Len(), " entries")
@@ -97,12 +150,26 @@ func (s *Store) Update(update func(tx *Tx)) {
 	}
 }
 
+// AllSynced returns true if all the sets are synced, without waiting for a revision like View.
+func (s *Store) AllSynced() bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	return (&Tx{s: s, ro: true}).AllSynced()
+}
+
+// Ready returns true if all the sets are synced from their sources, and not only restored from a
+// snapshot (the restored sets are synced so the last known state is served, but may be stale).
+func (s *Store) Ready() bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	tx := &Tx{s: s, ro: true}
+	return tx.AllSynced() && !tx.IsStale()
+}
+
 func (s *Store) View(afterRev uint64, view func(tx *Tx)) (rev uint64, closed bool) {
-	s.c.L.Lock()
-	for s.rev <= afterRev && !s.closed {
-		s.c.Wait()
-	}
-	s.c.L.Unlock()
+	s.WaitRevOrWake(afterRev, 0, nil)
 
 	s.RLock()
 	defer s.RUnlock()
@@ -116,10 +183,62 @@ func (s *Store) View(afterRev uint64, view func(tx *Tx)) (rev uint64, closed boo
 	return s.rev, s.closed
 }
 
+// WaitRev waits for the store to reach a revision after afterRev, for at most the given timeout (forever if 0).
+// The returned revision is afterRev (or before) if the timeout expired.
+func (s *Store) WaitRev(afterRev uint64, timeout time.Duration) (rev uint64, closed bool) {
+	return s.WaitRevOrWake(afterRev, timeout, nil)
+}
+
+// WaitRevOrWake is WaitRev, also returning when wake receives a value or is closed (never if nil).
+func (s *Store) WaitRevOrWake(afterRev uint64, timeout time.Duration, wake <-chan struct{}) (rev uint64, closed bool) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		expired = timer.C
+	}
+
+	for {
+		s.revL.Lock()
+		rev, closed, changed := s.rev, s.closed, s.changed
+		s.revL.Unlock()
+
+		if rev > afterRev || closed {
+			return rev, closed
+		}
+
+		select {
+		case <-changed:
+		case <-expired:
+			return rev, closed
+		case <-wake:
+			return rev, closed
+		}
+	}
+}
+
 type Tx struct {
 	s       *Store
 	ro      bool
 	changes uint
+
+	journal        bool
+	journalChanges []journalChange
+	reset          bool
+
+	// rawEndpoints are the endpoints admitted by SetRaw, so their twin index shares the decision
+	rawEndpoints map[string]rawEndpoint
+}
+
+type rawEndpoint struct {
+	ei  *localnetv1.EndpointInfo
+	err error
+}
+
+// Rev returns the revision of the store viewed by this transaction.
+func (tx *Tx) Rev() uint64 {
+	return tx.s.rev
 }
 
 func (tx *Tx) roPanic() {
@@ -148,8 +267,13 @@ func (tx *Tx) Reset() {
 	if tx.s.tree.Len() != 0 {
 		tx.s.tree.Clear(false)
 		tx.changes++
+		tx.reset = true
 	}
 
+	tx.s.entries = map[Set]int{}
+
+	tx.s.stale = nil
+
 	for set, isSync := range tx.s.sync {
 		if isSync {
 			tx.s.sync[set] = false
@@ -160,6 +284,8 @@ func (tx *Tx) Reset() {
 
 func (tx *Tx) set(kv *KV) {
 	tx.roPanic()
+	tx.confirm(kv)
+
 	prev := tx.s.tree.Get(kv)
 
 	if prev != nil && prev.(*KV).Value.GetHash() == kv.Value.GetHash() {
@@ -168,39 +294,102 @@ func (tx *Tx) set(kv *KV) {
 
 	tx.s.tree.ReplaceOrInsert(kv)
 	tx.changes++
+
+	if prev == nil {
+		tx.s.entries[kv.Set]++
+	}
+
+	if tx.journal {
+		change := journalChange{key: kv}
+		if prev != nil {
+			change.prev = prev.(*KV)
+		}
+		tx.journalChanges = append(tx.journalChanges, change)
+	}
 }
 
 func (tx *Tx) del(kv *KV) {
 	tx.roPanic()
+	tx.confirm(kv)
+
 	i := tx.s.tree.Delete(kv)
 	if i != nil {
 		tx.changes++
+		tx.s.entries[kv.Set]--
+
+		if tx.journal {
+			tx.journalChanges = append(tx.journalChanges, journalChange{key: kv, prev: i.(*KV)})
+		}
 	}
 }
 
+// SetRaw sets a value at the given path, as received from another store (ie: the global API or a snapshot).
+// The value goes through the admitters like the values set with SetService, SetEndpoint and SetNode: if
+// it's rejected, the previous value is kept, and if it's ignored, the previous value is removed.
 func (tx *Tx) SetRaw(set Set, path string, value Hashed) {
 	kv := &KV{}
 	kv.Set = set
 	kv.SetPath(path)
 
-	kv.Value = value
+	var err error
 
 	switch v := value.(type) {
 	case *localnetv1.NodeInfo:
-		kv.Node = v
-		tx.set(kv)
+		if err = tx.admitNode(v.Node); err == nil {
+			v.Hash = serde.Hash(v.Node)
+			kv.Node = v
+		}
 
 	case *localnetv1.ServiceInfo:
-		kv.Service = v
-		tx.set(kv)
+		if err = tx.admitService(v.Service); err == nil {
+			v.Hash = serde.Hash(&localnetv1.ServiceInfo{Service: v.Service})
+			kv.Service = v
+		}
 
 	case *localnetv1.EndpointInfo:
+		v, err = tx.admitRawEndpoint(kv, v)
 		kv.Endpoint = v
-		tx.set(kv)
+		value = v
 
 	default:
 		panic(fmt.Errorf("unknown value type: %t", v))
 	}
+
+	if err != nil {
+		if errors.Is(err, ErrIgnored) {
+			tx.del(kv)
+		}
+		return
+	}
+
+	kv.Value = value
+	tx.set(kv)
+}
+
+// admitRawEndpoint admits an endpoint set with SetRaw. Endpoints being indexed by service and by source,
+// each endpoint is received twice but only admitted once per transaction.
+func (tx *Tx) admitRawEndpoint(kv *KV, ei *localnetv1.EndpointInfo) (*localnetv1.EndpointInfo, error) {
+	key := kv.Namespace + "|" + kv.Source + "|" + kv.Key
+
+	if raw, ok := tx.rawEndpoints[key]; ok {
+		return raw.ei, raw.err
+	}
+
+	err := tx.admitEndpoint(ei)
+	if err == nil {
+		ei.Hash = serde.Hash(&localnetv1.EndpointInfo{
+			Endpoint:   ei.Endpoint,
+			Conditions: ei.Conditions,
+			Topology:   ei.Topology,
+		})
+	}
+
+	if tx.rawEndpoints == nil {
+		tx.rawEndpoints = map[string]rawEndpoint{}
+	}
+	tx.rawEndpoints[key] = rawEndpoint{ei, err}
+
+	return ei, err
 }
 
 func (tx *Tx) DelRaw(set Set, path string) {
@@ -226,6 +415,9 @@ func (tx *Tx) IsSynced(set Set) bool {
 func (tx *Tx) SetSync(set Set) {
 	tx.roPanic()
 
+	// the set is synced from a live source, so unconfirmed values are gone
+	tx.dropStale(set)
+
 	if !tx.s.sync[set] {
 		tx.s.sync[set] = true
 		tx.changes++
@@ -235,6 +427,13 @@ func (tx *Tx) SetSync(set Set) {
 // Services funcs
 
 func (tx *Tx) SetService(s *localnetv1.Service) {
+	if err := tx.admitService(s); err != nil {
+		if errors.Is(err, ErrIgnored) {
+			tx.DelService(s.Namespace, s.Name)
+		}
+		return
+	}
+
 	si := &localnetv1.ServiceInfo{
 		Service: s,
 		Hash: serde.Hash(&localnetv1.ServiceInfo{
@@ -251,6 +450,16 @@ func (tx *Tx) SetService(s *localnetv1.Service) {
 	})
 }
 
+func (tx *Tx) GetService(namespace, name string) *localnetv1.ServiceInfo {
+	i := tx.s.tree.Get(&KV{Set: Services, Namespace: namespace, Name: name})
+
+	if i == nil {
+		return nil
+	}
+
+	return i.(*KV).Service
+}
+
 func (tx *Tx) DelService(namespace, name string) {
 	tx.del(&KV{
 		Set:       Services,
@@ -279,12 +488,14 @@ func (tx *Tx) EachEndpointOfService(namespace, serviceName string, callback func
 	})
 }
 
-// SetEndpointsOfSource replaces ALL endpoints of a single source (add new, update existing, delete removed)
+// SetEndpointsOfSource replaces ALL endpoints of a single source (add new, update existing, delete removed).
+// Endpoints not admitted are removed too.
 func (tx *Tx) SetEndpointsOfSource(namespace, sourceName string, eis []*localnetv1.EndpointInfo) {
 	tx.roPanic()
 
 	seen := map[uint64]bool{}
 
+	admitted := make([]*localnetv1.EndpointInfo, 0, len(eis))
 	for _, ei := range eis {
 		if ei.Namespace != namespace {
 			panic("inconsistent namespace: " + namespace + " != " + ei.Namespace)
@@ -293,6 +504,11 @@ func (tx *Tx) SetEndpointsOfSource(namespace, sourceName string, eis []*localnet
 			panic("inconsistent source: " + sourceName + " != " + ei.SourceName)
 		}
 
+		if tx.admitEndpoint(ei) != nil {
+			continue
+		}
+		admitted = append(admitted, ei)
+
 		ei.Hash = serde.Hash(&localnetv1.EndpointInfo{
 			Endpoint:   ei.Endpoint,
 			Conditions: ei.Conditions,
@@ -300,6 +516,7 @@ func (tx *Tx) SetEndpointsOfSource(namespace, sourceName string, eis []*localnet
 		})
 		seen[ei.Hash] = true
 	}
+	eis = admitted
 
 	// to delete unseen endpoints
 	toDel := make([]*KV, 0)
@@ -349,6 +566,8 @@ func (tx *Tx) SetEndpointsOfSource(namespace, sourceName string, eis []*localnet
 		}
 
 		if tx.s.tree.Has(kv) {
+			tx.confirm(kv)
+			tx.confirm(&KV{Set: Endpoints, Namespace: ei.Namespace, Source: ei.SourceName, Key: key})
 			continue
 		}
 
@@ -402,18 +621,23 @@ func (tx *Tx) DelEndpointsOfSource(namespace, sourceName string) {
 func (tx *Tx) SetEndpoint(ei *localnetv1.EndpointInfo) {
 	tx.roPanic()
 
+	prevKey := strconv.FormatUint(ei.Hash, 16)
+
+	err := tx.admitEndpoint(ei)
+	if err != nil && !errors.Is(err, ErrIgnored) {
+		return // rejected, keep the previous value
+	}
+
 	newHash := serde.Hash(&localnetv1.EndpointInfo{
 		Endpoint:   ei.Endpoint,
 		Conditions: ei.Conditions,
 		Topology:   ei.Topology,
 	})
 
-	if ei.Hash == newHash {
+	if err == nil && ei.Hash == newHash {
 		return // not changed
 	}
 
-	prevKey := strconv.FormatUint(ei.Hash, 16)
-
 	tx.del(&KV{
 		Set:       Endpoints,
 		Namespace: ei.Namespace,
@@ -430,6 +654,10 @@ func (tx *Tx) SetEndpoint(ei *localnetv1.EndpointInfo) {
 		Key:       prevKey,
 	})
 
+	if err != nil {
+		return // ignored, only delete the previous value
+	}
+
 	// update key
 	ei.Hash = newHash
 	key := strconv.FormatUint(ei.Hash, 16)
@@ -469,6 +697,13 @@ func (tx *Tx) GetNode(name string) *localnetv1.Node {
 }
 
 func (tx *Tx) SetNode(n *localnetv1.Node) {
+	if err := tx.admitNode(n); err != nil {
+		if errors.Is(err, ErrIgnored) {
+			tx.DelNode(n.Name)
+		}
+		return
+	}
+
 	ni := &localnetv1.NodeInfo{
 		Node: n,
 		Hash: serde.Hash(n),
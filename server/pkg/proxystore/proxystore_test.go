@@ -19,7 +19,9 @@ package proxystore
 import (
 	"fmt"
 	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	localnetv1 "sigs.k8s.io/kpng/api/localnetv1"
 )
@@ -120,3 +122,214 @@ func TestSessionAffinitySetClientIP(t *testing.T) {
 		})
 	})
 }
+
+func TestViewRevision(t *testing.T) {
+	s := New()
+
+	svcNames := func(tx *Tx) (names []string) {
+		tx.Each(Services, func(kv *KV) bool {
+			names = append(names, kv.Name+"@"+kv.Service.Service.Type)
+			return true
+		})
+		return
+	}
+
+	s.Update(func(tx *Tx) {
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc0", Type: "ClusterIP"})
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc1", Type: "ClusterIP"})
+		tx.SetSync(Services)
+	})
+	s.Update(func(tx *Tx) {
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc0", Type: "NodePort"})
+		tx.DelService("default", "svc1")
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc2", Type: "ClusterIP"})
+	})
+
+	for _, test := range []struct {
+		rev      uint64
+		expected string
+		synced   bool
+	}{
+		{0, "[]", false},
+		{1, "[svc0@ClusterIP svc1@ClusterIP]", true},
+		{2, "[svc0@NodePort svc2@ClusterIP]", true},
+	} {
+		ok := s.ViewRevision(s.Epoch(), test.rev, func(tx *Tx) {
+			if names := fmt.Sprint(svcNames(tx)); names != test.expected {
+				t.Errorf("rev %d: expected %s, got %s", test.rev, test.expected, names)
+			}
+			if synced := tx.IsSynced(Services); synced != test.synced {
+				t.Errorf("rev %d: expected synced=%v, got %v", test.rev, test.synced, synced)
+			}
+		})
+		if !ok {
+			t.Errorf("rev %d: should be viewable", test.rev)
+		}
+	}
+
+	if s.ViewRevision(s.Epoch()+1, 2, func(tx *Tx) {}) {
+		t.Error("should not view another epoch")
+	}
+	if s.ViewRevision(s.Epoch(), 3, func(tx *Tx) {}) {
+		t.Error("should not view a future revision")
+	}
+
+	// reduce the journal so the first revisions are forgotten
+	s.SetJournalSize(3)
+	s.Update(func(tx *Tx) {
+		tx.DelService("default", "svc2")
+	})
+
+	if s.ViewRevision(s.Epoch(), 1, func(tx *Tx) {}) {
+		t.Error("rev 1 should have been forgotten")
+	}
+	if !s.ViewRevision(s.Epoch(), 2, func(tx *Tx) {}) {
+		t.Error("rev 2 should still be viewable")
+	}
+
+	// the current state must not be affected by views
+	s.View(0, func(tx *Tx) {
+		if names := fmt.Sprint(svcNames(tx)); names != "[svc0@NodePort]" {
+			t.Errorf("unexpected current state: %s", names)
+		}
+	})
+
+	// nor the views by updates
+	s.ViewRevision(s.Epoch(), 2, func(tx *Tx) {
+		s.Update(func(tx *Tx) {
+			tx.DelService("default", "svc0")
+		})
+
+		if names := fmt.Sprint(svcNames(tx)); names != "[svc0@NodePort svc2@ClusterIP]" {
+			t.Errorf("view changed by an update: %s", names)
+		}
+	})
+}
+
+func TestWaitRev(t *testing.T) {
+	s := New()
+
+	start := time.Now()
+	if rev, closed := s.WaitRev(0, 10*time.Millisecond); rev != 0 || closed {
+		t.Errorf("expected the timeout to expire at rev 0, got rev %d (closed: %v)", rev, closed)
+	}
+	if d := time.Since(start); d < 10*time.Millisecond {
+		t.Errorf("returned after %v, before the timeout", d)
+	}
+
+	// waiters with and without timeouts, all released by the next revision
+	wg := sync.WaitGroup{}
+	revs := make(chan uint64, 10)
+	for i := 0; i < cap(revs); i++ {
+		timeout := time.Duration(i%2) * time.Minute
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rev, _ := s.WaitRev(0, timeout)
+			revs <- rev
+		}()
+	}
+
+	s.Update(func(tx *Tx) {
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc0", Type: "ClusterIP"})
+	})
+
+	wg.Wait()
+	close(revs)
+
+	for rev := range revs {
+		if rev != 1 {
+			t.Errorf("expected the waiters to be released at rev 1, got %d", rev)
+		}
+	}
+
+	// closing the store releases the waiters
+	go s.Close()
+	if rev, closed := s.WaitRev(1, 0); rev != 1 || !closed {
+		t.Errorf("expected the store to be closed at rev 1, got rev %d (closed: %v)", rev, closed)
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	endpoint := func(source, ip string) *localnetv1.EndpointInfo {
+		return &localnetv1.EndpointInfo{
+			Namespace:   "default",
+			SourceName:  source,
+			ServiceName: "svc0",
+			Endpoint:    &localnetv1.Endpoint{IPs: localnetv1.NewIPSet(ip)},
+		}
+	}
+
+	src := New()
+	src.Update(func(tx *Tx) {
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc0"})
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc1"})
+		tx.SetEndpointsOfSource("default", "svc0-a", []*localnetv1.EndpointInfo{endpoint("svc0-a", "10.0.0.1")})
+		tx.SetEndpointsOfSource("default", "svc0-b", []*localnetv1.EndpointInfo{endpoint("svc0-b", "10.0.0.2")})
+		for _, set := range AllSets {
+			tx.SetSync(set)
+		}
+	})
+
+	var snapshot *localnetv1.StoreSnapshot
+	src.View(0, func(tx *Tx) {
+		var err error
+		if snapshot, err = tx.Snapshot(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	store := New()
+	store.Update(func(tx *Tx) {
+		if err := tx.Restore(snapshot); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	count := func(set Set) (n int) {
+		store.View(0, func(tx *Tx) {
+			tx.Each(set, func(*KV) bool { n++; return true })
+		})
+		return
+	}
+
+	store.View(0, func(tx *Tx) {
+		if !tx.AllSynced() || !tx.IsStale() {
+			t.Errorf("restored store should be synced and stale")
+		}
+	})
+	if store.Ready() {
+		t.Errorf("restored store should not be ready")
+	}
+	if n := count(Services); n != 2 {
+		t.Errorf("expected 2 restored services, got %d", n)
+	}
+	if n := count(Endpoints); n != 4 {
+		t.Errorf("expected 4 restored endpoint entries, got %d", n)
+	}
+
+	// live sources resync: svc1 and svc0-b are gone
+	store.Update(func(tx *Tx) {
+		tx.SetService(&localnetv1.Service{Namespace: "default", Name: "svc0"})
+		tx.SetEndpointsOfSource("default", "svc0-a", []*localnetv1.EndpointInfo{endpoint("svc0-a", "10.0.0.1")})
+		for _, set := range AllSets {
+			tx.SetSync(set)
+		}
+	})
+
+	store.View(0, func(tx *Tx) {
+		if tx.IsStale() {
+			t.Errorf("store should not be stale after resync")
+		}
+	})
+	if !store.Ready() {
+		t.Errorf("store should be ready after resync")
+	}
+	if n := count(Services); n != 1 {
+		t.Errorf("expected 1 service after resync, got %d", n)
+	}
+	if n := count(Endpoints); n != 2 {
+		t.Errorf("expected 2 endpoint entries after resync, got %d", n)
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxystore
+
+import (
+	"fmt"
+
+	"github.com/google/btree"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/klog/v2"
+
+	localnetv1 "sigs.k8s.io/kpng/api/localnetv1"
+)
+
+// Snapshot returns a copy of the store's content and sync state.
+func (tx *Tx) Snapshot() (snapshot *localnetv1.StoreSnapshot, err error) {
+	snapshot = &localnetv1.StoreSnapshot{
+		Values: make([]*localnetv1.Value, 0, tx.s.tree.Len()),
+	}
+
+	for _, set := range AllSets {
+		if tx.IsSynced(set) {
+			snapshot.SyncedSets = append(snapshot.SyncedSets, set)
+		}
+	}
+
+	tx.s.tree.Ascend(func(i btree.Item) bool {
+		kv := i.(*KV)
+
+		var ba []byte
+		ba, err = proto.Marshal(kv.Value.(proto.Message))
+		if err != nil {
+			return false
+		}
+
+		snapshot.Values = append(snapshot.Values, &localnetv1.Value{
+			Ref:   &localnetv1.Ref{Set: kv.Set, Path: kv.Path()},
+			Bytes: ba,
+		})
+		return true
+	})
+
+	return
+}
+
+// Restore replaces the store's content and sync state with the snapshot's.
+//
+// The restored values are stale until confirmed: each set stays as it was restored until it's synced
+// again (see SetSync), at which time the restored values not updated since are removed.
+func (tx *Tx) Restore(snapshot *localnetv1.StoreSnapshot) (err error) {
+	tx.Reset()
+
+	stale := make(map[Set]map[string]bool, len(AllSets))
+	for _, set := range AllSets {
+		stale[set] = map[string]bool{}
+	}
+
+	for _, value := range snapshot.Values {
+		var v interface {
+			proto.Message
+			Hashed
+		}
+
+		switch value.Ref.Set {
+		case Services:
+			v = &localnetv1.ServiceInfo{}
+		case Endpoints:
+			v = &localnetv1.EndpointInfo{}
+		case Nodes:
+			v = &localnetv1.NodeInfo{}
+		default:
+			return fmt.Errorf("unknown set in snapshot: %v", value.Ref.Set)
+		}
+
+		if err = proto.Unmarshal(value.Bytes, v); err != nil {
+			return
+		}
+
+		tx.SetRaw(value.Ref.Set, value.Ref.Path, v)
+		stale[value.Ref.Set][value.Ref.Path] = true
+	}
+
+	for _, set := range snapshot.SyncedSets {
+		tx.SetSync(set)
+	}
+
+	tx.s.stale = stale
+
+	klog.Info("restored ", len(snapshot.Values), " values from snapshot (stale until resynced)")
+
+	return
+}
+
+// IsStale returns true if the store has values restored from a snapshot that are not confirmed yet.
+func (tx *Tx) IsStale() bool {
+	return tx.s.stale != nil
+}
+
+// IsStale returns true if the store has values restored from a snapshot that are not confirmed yet,
+// without waiting for a revision like View.
+func (s *Store) IsStale() bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	return (&Tx{s: s, ro: true}).IsStale()
+}
+
+// confirm marks a value as not stale anymore.
+func (tx *Tx) confirm(kv *KV) {
+	if tx.s.stale == nil {
+		return
+	}
+
+	delete(tx.s.stale[kv.Set], kv.Path())
+}
+
+// dropStale removes the values of a set that were restored and not confirmed since.
+func (tx *Tx) dropStale(set Set) {
+	stale, ok := tx.s.stale[set]
+	if !ok {
+		return
+	}
+
+	delete(tx.s.stale, set)
+
+	for path := range stale {
+		tx.DelRaw(set, path)
+	}
+
+	klog.Info("removed ", len(stale), " stale values from ", set)
+
+	if len(tx.s.stale) == 0 {
+		tx.s.stale = nil
+		klog.Info("store fully resynced")
+	}
+}
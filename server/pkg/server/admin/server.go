@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+	"sigs.k8s.io/kpng/server/pkg/server/watches"
+)
+
+type Server struct {
+	localnetv1.UnimplementedAdminServer
+
+	Store   *proxystore.Store
+	Watches *watches.Registry
+}
+
+func (s *Server) ListWatches(_ context.Context, _ *localnetv1.ListWatchesReq) (*localnetv1.ListWatchesRes, error) {
+	res := &localnetv1.ListWatchesRes{
+		Revision: &localnetv1.Revision{Epoch: s.Store.Epoch(), Rev: s.Store.Rev()},
+	}
+
+	for _, watch := range s.Watches.List() {
+		info := watch.Info()
+		info.ChangesBehind = -1
+
+		lastRev := info.LastRevision
+		switch {
+		case lastRev == nil || lastRev.Epoch != res.Revision.Epoch:
+			info.RevisionsBehind = res.Revision.Rev
+
+		default:
+			if lastRev.Rev < res.Revision.Rev {
+				info.RevisionsBehind = res.Revision.Rev - lastRev.Rev
+			}
+
+			if count, ok := s.Store.ChangesCountSince(lastRev.Rev); ok {
+				info.ChangesBehind = int64(count)
+			}
+		}
+
+		res.Watches = append(res.Watches, info)
+	}
+
+	return res, nil
+}
+
+func (s *Server) ListApplyStatuses(_ context.Context, _ *localnetv1.ListApplyStatusesReq) (*localnetv1.ListApplyStatusesRes, error) {
+	return &localnetv1.ListApplyStatusesRes{Nodes: s.Watches.ApplyStatuses()}, nil
+}
+
+func (s *Server) ResetWatch(_ context.Context, req *localnetv1.ResetWatchReq) (*localnetv1.ResetWatchRes, error) {
+	watch := s.Watches.Get(req.ID)
+	if watch == nil {
+		return nil, grpc.Errorf(codes.NotFound, "no active watch with ID %d", req.ID)
+	}
+
+	klog.Info("reset requested for watch ", watch.ID, " from ", watch.Peer)
+	watch.RequestReset()
+
+	return &localnetv1.ResetWatchRes{}, nil
+}
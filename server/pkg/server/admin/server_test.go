@@ -0,0 +1,163 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+	"sigs.k8s.io/kpng/server/pkg/server/watches"
+)
+
+func TestListAndResetWatches(t *testing.T) {
+	store := proxystore.New()
+	registry := watches.NewRegistry()
+	srv := &Server{Store: store, Watches: registry}
+
+	setService := func(name string) {
+		store.Update(func(tx *proxystore.Tx) {
+			tx.SetService(&localnetv1.Service{Namespace: "default", Name: name})
+		})
+	}
+
+	setService("svc0")
+
+	synced := registry.Add("local", "10.0.0.1:1234")
+	synced.SetNodeName("node-a")
+	synced.Synced(&localnetv1.Revision{Epoch: store.Epoch(), Rev: store.Rev()})
+
+	registry.Add("global", "10.0.0.2:1234") // never synced
+
+	setService("svc1")
+	setService("svc2")
+
+	res, err := srv.ListWatches(context.Background(), &localnetv1.ListWatchesReq{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.Watches) != 2 {
+		t.Fatalf("expected 2 watches, got %d", len(res.Watches))
+	}
+
+	if w := res.Watches[0]; w.NodeName != "node-a" || w.RevisionsBehind != 2 || w.ChangesBehind != 2 || w.LastSyncTime == 0 {
+		t.Errorf("unexpected synced watch info: %v", w)
+	}
+	if w := res.Watches[1]; w.API != "global" || w.RevisionsBehind != 3 || w.ChangesBehind != -1 || w.LastRevision != nil {
+		t.Errorf("unexpected unsynced watch info: %v", w)
+	}
+
+	if _, err = srv.ResetWatch(context.Background(), &localnetv1.ResetWatchReq{ID: 42}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected a NotFound error for an unknown watch, got %v", err)
+	}
+
+	if _, err = srv.ResetWatch(context.Background(), &localnetv1.ResetWatchReq{ID: synced.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if !synced.ResetRequested() || synced.ResetRequested() {
+		t.Error("expected the reset to be requested once")
+	}
+}
+
+func TestListApplyStatuses(t *testing.T) {
+	registry := watches.NewRegistry()
+	srv := &Server{Store: proxystore.New(), Watches: registry}
+
+	notified := 0
+	registry.AddApplyListener(func(nodeName string, status *localnetv1.ApplyStatus) { notified++ })
+
+	failed := &localnetv1.ApplyStatus{Revision: &localnetv1.Revision{Rev: 1}, Error: "boom", FailedServices: []string{"default/svc0"}}
+
+	wb := registry.Add("local", "10.0.0.2:1234")
+	wb.Applied("node-b", failed)
+	wb.Applied("node-b", failed)
+
+	wa := registry.Add("local", "10.0.0.1:1234")
+	wa.Applied("node-a", &localnetv1.ApplyStatus{Revision: &localnetv1.Revision{Rev: 1}})
+
+	if notified != 1 {
+		t.Errorf("expected 1 notification (node-b failing), got %d", notified)
+	}
+
+	res, err := srv.ListApplyStatuses(context.Background(), &localnetv1.ListApplyStatusesReq{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.Nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %d", len(res.Nodes))
+	}
+
+	if n := res.Nodes[0]; n.NodeName != "node-a" || n.Failures != 0 || n.Status.Error != "" {
+		t.Errorf("unexpected node-a status: %v", n)
+	}
+	if n := res.Nodes[1]; n.NodeName != "node-b" || n.Failures != 2 || n.Status.Error != "boom" {
+		t.Errorf("unexpected node-b status: %v", n)
+	}
+
+	wb.Applied("node-b", &localnetv1.ApplyStatus{Revision: &localnetv1.Revision{Rev: 2}})
+
+	if notified != 2 {
+		t.Errorf("expected node-b's recovery to be notified")
+	}
+}
+
+func TestForgetRemovedNodes(t *testing.T) {
+	store := proxystore.New()
+	registry := watches.NewRegistry()
+	srv := &Server{Store: store, Watches: registry}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go registry.ForgetRemovedNodes(ctx, store)
+
+	store.Update(func(tx *proxystore.Tx) {
+		tx.SetNode(&localnetv1.Node{Name: "node-a"})
+		tx.SetNode(&localnetv1.Node{Name: "node-b"})
+	})
+
+	w := registry.Add("local", "10.0.0.1:1234")
+	w.Applied("node-a", &localnetv1.ApplyStatus{Revision: &localnetv1.Revision{Rev: 1}})
+	w.Applied("node-b", &localnetv1.ApplyStatus{Revision: &localnetv1.Revision{Rev: 1}, Error: "boom"})
+
+	store.Update(func(tx *proxystore.Tx) {
+		tx.DelNode("node-b")
+	})
+
+	for i := 0; ; i++ {
+		res, err := srv.ListApplyStatuses(context.Background(), &localnetv1.ListApplyStatusesReq{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(res.Nodes) == 1 && res.Nodes[0].NodeName == "node-a" {
+			break
+		}
+
+		if i == 100 {
+			t.Fatalf("expected only node-a's status to be kept, got %v", res.Nodes)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
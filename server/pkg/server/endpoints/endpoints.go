@@ -20,9 +20,18 @@ import (
 	"google.golang.org/grpc"
 
 	localnetv1 "sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/jobs/store2diff"
+	"sigs.k8s.io/kpng/server/jobs/store2localdiff"
 	proxystore "sigs.k8s.io/kpng/server/pkg/proxystore"
+	"sigs.k8s.io/kpng/server/pkg/server/watches"
 )
 
-func Setup(s grpc.ServiceRegistrar, store *proxystore.Store) {
-	localnetv1.RegisterEndpointsServer(s, &Server{Store: store})
+// Setup registers the local API server; its watches are tracked in the registry if not nil.
+func Setup(s grpc.ServiceRegistrar, store *proxystore.Store, registry *watches.Registry, coalesce *store2diff.Coalesce) {
+	localnetv1.RegisterEndpointsServer(s, &Server{
+		Store:    store,
+		States:   store2localdiff.NewNodeStates(),
+		Watches:  registry,
+		Coalesce: coalesce,
+	})
 }
@@ -24,19 +24,31 @@ import (
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/jobs/store2diff"
 	"sigs.k8s.io/kpng/server/jobs/store2localdiff"
 	"sigs.k8s.io/kpng/server/pkg/proxystore"
+	"sigs.k8s.io/kpng/server/pkg/server"
+	"sigs.k8s.io/kpng/server/pkg/server/watches"
 )
 
 type Server struct {
 	localnetv1.UnimplementedEndpointsServer
 
 	Store *proxystore.Store
+
+	// States shares the local states between the watchers of the same node
+	States *store2localdiff.NodeStates
+
+	// Watches tracks the active watches (not tracked if nil)
+	Watches *watches.Registry
+
+	// Coalesce batches the store changes sent to the watchers (sent immediately if nil)
+	Coalesce *store2diff.Coalesce
 }
 
 var syncItem = &localnetv1.OpItem{Op: &localnetv1.OpItem_Sync{}}
 
-func (s *Server) Watch(res localnetv1.Endpoints_WatchServer) error {
+func (s *Server) Watch(res localnetv1.Endpoints_WatchServer) (err error) {
 	remote := ""
 	{
 		ctxPeer, _ := peer.FromContext(res.Context())
@@ -46,9 +58,18 @@ func (s *Server) Watch(res localnetv1.Endpoints_WatchServer) error {
 	klog.Info("new connection from ", remote)
 	defer klog.Info("connection from ", remote, " closed")
 
+	done := server.TrackWatch("local")
+	defer func() { done(err) }()
+
+	watch := s.Watches.Add("local", remote)
+	defer s.Watches.Remove(watch)
+
 	job := &store2localdiff.Job{
-		Store: s.Store,
-		Sink:  serverSink{res, remote},
+		Store:  s.Store,
+		Sink:   &serverSink{Endpoints_WatchServer: res, Watch: watch, remote: remote},
+		States: s.States,
+
+		Coalesce: s.Coalesce,
 	}
 
 	return job.Run(res.Context())
@@ -56,12 +77,21 @@ func (s *Server) Watch(res localnetv1.Endpoints_WatchServer) error {
 
 type serverSink struct {
 	localnetv1.Endpoints_WatchServer
-	remote string
+	*watches.Watch
+	remote  string
+	lastRev *localnetv1.Revision
+	filter  *store2diff.Filter
 }
 
-func (s serverSink) Setup() { /* noop */ }
+var (
+	_ store2diff.ResumableSink = &serverSink{}
+	_ store2diff.FilteredSink  = &serverSink{}
+	_ store2diff.TrackedSink   = &serverSink{}
+)
+
+func (s *serverSink) Setup() { /* noop */ }
 
-func (s serverSink) WaitRequest() (nodeName string, err error) {
+func (s *serverSink) WaitRequest() (nodeName string, err error) {
 	req, err := s.Recv()
 
 	if err != nil {
@@ -71,8 +101,31 @@ func (s serverSink) WaitRequest() (nodeName string, err error) {
 
 	klog.V(1).Info("remote ", s.remote, " requested node ", req.NodeName)
 
+	s.filter, err = store2diff.NewFilter(req.Filter)
+	if err != nil {
+		err = grpc.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+		return
+	}
+
 	nodeName = req.NodeName
+	s.SetNodeName(nodeName)
+
+	if req.ApplyStatus != nil {
+		if req.ApplyStatus.Error != "" {
+			klog.Warning("remote ", s.remote, " (node ", nodeName, ") failed to apply revision ", req.ApplyStatus.GetRevision().GetRev(), ": ", req.ApplyStatus.Error)
+		}
+		s.Applied(nodeName, req.ApplyStatus)
+	}
+	s.lastRev = req.LastRevision
 	return
 }
 
-func (s serverSink) Reset() {}
+func (s *serverSink) LastRevision() *localnetv1.Revision {
+	return s.lastRev
+}
+
+func (s *serverSink) Filter() *store2diff.Filter {
+	return s.filter
+}
+
+func (s *serverSink) Reset() {}
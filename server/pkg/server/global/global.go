@@ -20,9 +20,12 @@ import (
 	"google.golang.org/grpc"
 
 	localnetv1 "sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/jobs/store2diff"
 	proxystore "sigs.k8s.io/kpng/server/pkg/proxystore"
+	"sigs.k8s.io/kpng/server/pkg/server/watches"
 )
 
-func Setup(s grpc.ServiceRegistrar, store *proxystore.Store) {
-	localnetv1.RegisterGlobalServer(s, &Server{Store: store})
+// Setup registers the global API server; its watches are tracked in the registry if not nil.
+func Setup(s grpc.ServiceRegistrar, store *proxystore.Store, registry *watches.Registry, coalesce *store2diff.Coalesce) {
+	localnetv1.RegisterGlobalServer(s, &Server{Store: store, Watches: registry, Coalesce: coalesce})
 }
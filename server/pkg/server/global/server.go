@@ -17,25 +17,50 @@ limitations under the License.
 package global
 
 import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+
 	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/jobs/store2diff"
 	"sigs.k8s.io/kpng/server/jobs/store2globaldiff"
 	"sigs.k8s.io/kpng/server/pkg/proxystore"
+	"sigs.k8s.io/kpng/server/pkg/server"
+	"sigs.k8s.io/kpng/server/pkg/server/watches"
 )
 
 type Server struct {
 	localnetv1.UnimplementedGlobalServer
 
 	Store *proxystore.Store
+
+	// Watches tracks the active watches (not tracked if nil)
+	Watches *watches.Registry
+
+	// Coalesce batches the store changes sent to the watchers (sent immediately if nil)
+	Coalesce *store2diff.Coalesce
 }
 
 var syncItem = &localnetv1.OpItem{Op: &localnetv1.OpItem_Sync{}}
 
-func (s *Server) Watch(res localnetv1.Global_WatchServer) error {
-	w := resWrap{res}
+func (s *Server) Watch(res localnetv1.Global_WatchServer) (err error) {
+	done := server.TrackWatch("global")
+	defer func() { done(err) }()
+
+	remote := ""
+	if ctxPeer, ok := peer.FromContext(res.Context()); ok {
+		remote = ctxPeer.Addr.String()
+	}
+
+	watch := s.Watches.Add("global", remote)
+	defer s.Watches.Remove(watch)
+
+	w := &resWrap{Global_WatchServer: res, Watch: watch}
 
 	job := &store2globaldiff.Job{
-		Store: s.Store,
-		Sink:  w,
+		Store:    s.Store,
+		Sink:     w,
+		Coalesce: s.Coalesce,
 	}
 
 	return job.Run(res.Context())
@@ -43,9 +68,43 @@ func (s *Server) Watch(res localnetv1.Global_WatchServer) error {
 
 type resWrap struct {
 	localnetv1.Global_WatchServer
+	*watches.Watch
+	lastRev *localnetv1.Revision
+	filter  *store2diff.Filter
+	sets    []localnetv1.Set
+}
+
+var (
+	_ store2diff.ResumableSink      = &resWrap{}
+	_ store2diff.FilteredSink       = &resWrap{}
+	_ store2globaldiff.SetsSelector = &resWrap{}
+	_ store2diff.TrackedSink        = &resWrap{}
+)
+
+func (w *resWrap) Wait() error {
+	req, err := w.Recv()
+	if err != nil {
+		return err
+	}
+
+	w.filter, err = store2diff.NewFilter(req.Filter)
+	if err != nil {
+		return grpc.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+
+	w.lastRev = req.LastRevision
+	w.sets = req.Sets
+	return nil
+}
+
+func (w *resWrap) LastRevision() *localnetv1.Revision {
+	return w.lastRev
+}
+
+func (w *resWrap) Filter() *store2diff.Filter {
+	return w.filter
 }
 
-func (w resWrap) Wait() error {
-	_, err := w.Recv()
-	return err
+func (w *resWrap) Sets() []localnetv1.Set {
+	return w.sets
 }
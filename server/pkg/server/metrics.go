@@ -0,0 +1,57 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	watchersGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kpng",
+		Subsystem: "api",
+		Name:      "watchers",
+		Help:      "Number of clients watching the API",
+	}, []string{"api"})
+
+	watchesCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kpng",
+		Subsystem: "api",
+		Name:      "watches_total",
+		Help:      "Number of watches ended, by result",
+	}, []string{"api", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(watchersGauge, watchesCount)
+}
+
+// TrackWatch records a new watch on the given API (ie: local or global); the returned function must
+// be called with the watch's result when it ends.
+func TrackWatch(api string) (done func(err error)) {
+	watchersGauge.WithLabelValues(api).Inc()
+
+	return func(err error) {
+		watchersGauge.WithLabelValues(api).Dec()
+
+		result := "ok"
+		if err != nil {
+			result = "error"
+		}
+		watchesCount.WithLabelValues(api, result).Inc()
+	}
+}
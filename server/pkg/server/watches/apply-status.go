@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watches
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+	"sigs.k8s.io/kpng/server/pkg/proxystore"
+)
+
+// ApplyListener is notified when the apply status of a node changes (ie: a new error, or a success after errors).
+type ApplyListener func(nodeName string, status *localnetv1.ApplyStatus)
+
+var (
+	applyReportsCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kpng",
+		Subsystem: "api",
+		Name:      "apply_reports_total",
+		Help:      "Number of apply statuses reported by the nodes, by result",
+	}, []string{"result"})
+
+	failingNodesGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kpng",
+		Subsystem: "api",
+		Name:      "failing_nodes",
+		Help:      "Number of nodes whose last reported apply failed",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(applyReportsCount, failingNodesGauge)
+}
+
+// AddApplyListener adds a listener of the nodes' apply status changes.
+func (r *Registry) AddApplyListener(listener ApplyListener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.listeners = append(r.listeners, listener)
+}
+
+// ApplyStatuses returns the last apply status reported by each node, by node name.
+func (r *Registry) ApplyStatuses() (statuses []*localnetv1.NodeApplyStatus) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statuses = make([]*localnetv1.NodeApplyStatus, 0, len(r.nodes))
+	for _, status := range r.nodes {
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].NodeName < statuses[j].NodeName })
+
+	return
+}
+
+func (r *Registry) applied(nodeName string, status *localnetv1.ApplyStatus) {
+	result := "success"
+	if status.Error != "" {
+		result = "failure"
+	}
+	applyReportsCount.WithLabelValues(result).Inc()
+
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+
+	prev := r.nodes[nodeName]
+	node := &localnetv1.NodeApplyStatus{
+		NodeName: nodeName,
+		Status:   status,
+		Time:     time.Now().UnixNano(),
+	}
+
+	if status.Error != "" {
+		node.Failures = 1
+		if prev != nil {
+			node.Failures += prev.Failures
+		}
+	}
+
+	r.nodes[nodeName] = node
+
+	wasFailing := prev != nil && prev.Failures != 0
+	switch {
+	case node.Failures != 0 && !wasFailing:
+		r.failingNodes++
+	case node.Failures == 0 && wasFailing:
+		r.failingNodes--
+	}
+	failingNodesGauge.Set(float64(r.failingNodes))
+
+	listeners := r.listeners
+
+	r.mu.Unlock()
+
+	changed := (prev == nil && status.Error != "") || (prev != nil && prev.Status.Error != status.Error)
+	if !changed {
+		return
+	}
+
+	for _, listener := range listeners {
+		listener(nodeName, status)
+	}
+}
+
+// ForgetNode drops the apply status reported by the node.
+func (r *Registry) ForgetNode(nodeName string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	node, ok := r.nodes[nodeName]
+	if !ok {
+		return
+	}
+
+	delete(r.nodes, nodeName)
+
+	if node.Failures != 0 {
+		r.failingNodes--
+		failingNodesGauge.Set(float64(r.failingNodes))
+	}
+}
+
+// ForgetRemovedNodes drops the apply status of the nodes removed from the store, until the context is done
+// or the store is closed.
+func (r *Registry) ForgetRemovedNodes(ctx context.Context, store *proxystore.Store) {
+	var rev uint64
+	known := map[string]bool{}
+
+	for {
+		if _, closed := store.WaitRevOrWake(rev, 0, ctx.Done()); closed || ctx.Err() != nil {
+			return
+		}
+
+		prevRev := rev
+		rev, _ = store.View(rev, func(tx *proxystore.Tx) {
+			nodes := make(map[string]bool, len(known))
+			tx.Each(proxystore.Nodes, func(kv *proxystore.KV) bool {
+				nodes[kv.Name] = true
+				return true
+			})
+
+			removed := map[string]bool{}
+			for nodeName := range known {
+				if !nodes[nodeName] {
+					removed[nodeName] = true
+				}
+			}
+
+			// the journal also gives the nodes added and removed since the last view
+			tx.ChangesSince(prevRev, func(kv *proxystore.KV) {
+				if kv.Set == proxystore.Nodes && !nodes[kv.Name] {
+					removed[kv.Name] = true
+				}
+			})
+
+			for nodeName := range removed {
+				r.ForgetNode(nodeName)
+			}
+
+			known = nodes
+		})
+	}
+}
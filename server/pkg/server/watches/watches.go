@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package watches tracks the active watches of the API servers.
+package watches
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/kpng/api/localnetv1"
+)
+
+// Registry tracks the active watches of a server, and the apply statuses reported by the nodes.
+// A nil Registry doesn't track anything.
+type Registry struct {
+	mu      sync.Mutex
+	lastID  uint64
+	watches map[uint64]*Watch
+
+	nodes        map[string]*localnetv1.NodeApplyStatus
+	failingNodes int
+	listeners    []ApplyListener
+}
+
+// DefaultRegistry is the registry used by the API servers when none is given.
+var DefaultRegistry = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{
+		watches: map[uint64]*Watch{},
+		nodes:   map[string]*localnetv1.NodeApplyStatus{},
+	}
+}
+
+// Add registers a new watch on the given API, from the given peer.
+func (r *Registry) Add(api, peer string) *Watch {
+	w := &Watch{
+		API:   api,
+		Peer:  peer,
+		Start: time.Now(),
+
+		resets: make(chan struct{}, 1),
+	}
+
+	if r == nil {
+		return w
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+	w.ID = r.lastID
+	w.registry = r
+	r.watches[w.ID] = w
+
+	return w
+}
+
+// Remove unregisters a watch.
+func (r *Registry) Remove(w *Watch) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.watches, w.ID)
+}
+
+// Get returns the watch with the given ID, or nil if it's not active.
+func (r *Registry) Get(id uint64) *Watch {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.watches[id]
+}
+
+// List returns the active watches, by ID.
+func (r *Registry) List() (watches []*Watch) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	watches = make([]*Watch, 0, len(r.watches))
+	for _, w := range r.watches {
+		watches = append(watches, w)
+	}
+
+	sort.Slice(watches, func(i, j int) bool { return watches[i].ID < watches[j].ID })
+
+	return
+}
+
+// Watch is the state of an active watch. It implements store2diff.TrackedSink.
+type Watch struct {
+	ID    uint64
+	API   string
+	Peer  string
+	Start time.Time
+
+	registry *Registry
+	resets   chan struct{}
+
+	mu        sync.Mutex
+	nodeName  string
+	lastRev   *localnetv1.Revision
+	lastSync  time.Time
+	lastApply *localnetv1.ApplyStatus
+	reset     bool
+}
+
+// SetNodeName records the node requested by the watcher.
+func (w *Watch) SetNodeName(nodeName string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.nodeName = nodeName
+}
+
+// Synced records the revision of the last change set sent.
+func (w *Watch) Synced(rev *localnetv1.Revision) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.lastRev = rev
+	w.lastSync = time.Now()
+}
+
+// Applied records the apply status reported by the watcher, for the given node.
+func (w *Watch) Applied(nodeName string, status *localnetv1.ApplyStatus) {
+	w.mu.Lock()
+	w.lastApply = status
+	w.mu.Unlock()
+
+	w.registry.applied(nodeName, status)
+}
+
+// RequestReset requests the whole data set to be sent again to the watcher.
+func (w *Watch) RequestReset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.reset = true
+
+	// wake the watch up if it's waiting for the store
+	select {
+	case w.resets <- struct{}{}:
+	default:
+	}
+}
+
+// ResetNotify returns a channel receiving a value when a reset is requested.
+func (w *Watch) ResetNotify() <-chan struct{} {
+	return w.resets
+}
+
+// ResetRequested returns true if a reset was requested since the last call.
+func (w *Watch) ResetRequested() (reset bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	reset, w.reset = w.reset, false
+
+	select {
+	case <-w.resets:
+	default:
+	}
+	return
+}
+
+// Info returns the watch's info, without the lag (that depends on the store).
+func (w *Watch) Info() *localnetv1.WatchInfo {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	info := &localnetv1.WatchInfo{
+		ID:           w.ID,
+		API:          w.API,
+		Peer:         w.Peer,
+		NodeName:     w.nodeName,
+		StartTime:    w.Start.UnixNano(),
+		LastRevision: w.lastRev,
+		LastApply:    w.lastApply,
+	}
+
+	if !w.lastSync.IsZero() {
+		info.LastSyncTime = w.lastSync.UnixNano()
+	}
+
+	return info
+}
@@ -151,6 +151,11 @@ func (w *WatchState) SendSync() {
 	w.send(syncItem)
 }
 
+// SendSyncRevision signals the change set is complete, with the revision of the data set.
+func (w *WatchState) SendSyncRevision(rev *localnetv1.Revision) {
+	w.send(&localnetv1.OpItem{Op: &localnetv1.OpItem_Sync{}, Revision: rev})
+}
+
 var resetItem = &localnetv1.OpItem{Op: &localnetv1.OpItem_Reset_{}}
 
 func (w *WatchState) SendReset() {